@@ -0,0 +1,79 @@
+package coincheck
+
+import (
+	"context"
+	"net/http"
+)
+
+// ExchangeFee represents the trading fee rates of a pair.
+// The rates are percentages. e.g. "0.05" means 0.05%.
+type ExchangeFee struct {
+	// MakerFeeRate is the fee rate (%) when your order is a maker.
+	MakerFeeRate Decimal `json:"maker_fee_rate"`
+	// TakerFeeRate is the fee rate (%) when your order is a taker.
+	TakerFeeRate Decimal `json:"taker_fee_rate"`
+}
+
+// FeeRate returns the fee rate (%) of the liquidity. If liquidity is not LiquidityMaker, the taker fee rate is returned.
+func (f ExchangeFee) FeeRate(liquidity Liquidity) Decimal {
+	if liquidity == LiquidityMaker {
+		return f.MakerFeeRate
+	}
+	return f.TakerFeeRate
+}
+
+// Fee returns the fee of a fill of amount at rate. The fee is in the quote currency of the pair (e.g. JPY).
+// e.g. With the taker fee rate 0.1 (%), a fill of 0.5 BTC at 4,000,000 JPY costs 2,000 JPY.
+func (f ExchangeFee) Fee(liquidity Liquidity, rate, amount Decimal) Decimal {
+	return rate.Mul(amount).Mul(f.FeeRate(liquidity)).Mul(NewDecimal(1, 2))
+}
+
+// GetAccountsResponse represents the output from the GetAccounts method.
+type GetAccountsResponse struct {
+	// Success is a boolean value that indicates the success of the API call.
+	Success bool `json:"success"`
+	// ID is the account ID. It's the same as the ID you used for sending money to Coincheck.
+	ID int `json:"id"`
+	// Email is the registered e-mail address.
+	Email string `json:"email"`
+	// IdentityStatus is the status of your identity verification. e.g. identity_pending
+	IdentityStatus string `json:"identity_status"`
+	// BitcoinAddress is your bitcoin deposit address.
+	BitcoinAddress string `json:"bitcoin_address"`
+	// TakerFee is the taker fee rate (%) of btc_jpy.
+	TakerFee Decimal `json:"taker_fee"`
+	// MakerFee is the maker fee rate (%) of btc_jpy.
+	MakerFee Decimal `json:"maker_fee"`
+	// ExchangeFees is the fee rates of each pair.
+	ExchangeFees map[Pair]ExchangeFee `json:"exchange_fees"`
+}
+
+// EstimateFee returns the fee of a hypothetical fill of amount at rate on the pair.
+// The fee is in the quote currency of the pair (e.g. JPY). It returns false if the pair is not in ExchangeFees.
+func (r *GetAccountsResponse) EstimateFee(pair Pair, liquidity Liquidity, rate, amount Decimal) (Decimal, bool) {
+	fee, ok := r.ExchangeFees[pair]
+	if !ok {
+		return Decimal{}, false
+	}
+	return fee.Fee(liquidity, rate, amount), true
+}
+
+// GetAccounts returns the information of your account, including the fee rates of each pair.
+// API: GET /api/accounts
+// Visibility: Private
+func (c *Client) GetAccounts(ctx context.Context) (*GetAccountsResponse, error) {
+	req, err := c.createRequest(ctx, createRequestInput{
+		method:  http.MethodGet,
+		path:    "/api/accounts",
+		private: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var output GetAccountsResponse
+	if err := c.do(req, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
@@ -0,0 +1,121 @@
+package coincheck
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_GetAccounts(t *testing.T) {
+	t.Run("GetAccounts returns the account information and the fee rates", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			wantMethod := http.MethodGet
+			if diff := cmp.Diff(wantMethod, r.Method); diff != "" {
+				printDiff(t, diff)
+			}
+
+			wantEndpoint := "/api/accounts"
+			if diff := cmp.Diff(wantEndpoint, r.URL.Path); diff != "" {
+				printDiff(t, diff)
+			}
+
+			w.Write([]byte(`{
+				"success": true,
+				"id": 10000,
+				"email": "test@gmail.com",
+				"identity_status": "identity_pending",
+				"bitcoin_address": "1v6zFvyNPgdRvhUufkRoTtgyiw1xigncc",
+				"taker_fee": "0.15",
+				"maker_fee": "0.0",
+				"exchange_fees": {
+					"btc_jpy": {"maker_fee_rate": "0.0", "taker_fee_rate": "0.0"},
+					"etc_jpy": {"maker_fee_rate": "0.05", "taker_fee_rate": "0.1"}
+				}
+			}`)) //nolint: errcheck // ignore error
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := client.GetAccounts(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := &GetAccountsResponse{
+			Success:        true,
+			ID:             10000,
+			Email:          "test@gmail.com",
+			IdentityStatus: "identity_pending",
+			BitcoinAddress: "1v6zFvyNPgdRvhUufkRoTtgyiw1xigncc",
+			TakerFee:       MustParseDecimal("0.15"),
+			MakerFee:       MustParseDecimal("0.0"),
+			ExchangeFees: map[Pair]ExchangeFee{
+				PairBTCJPY: {MakerFeeRate: MustParseDecimal("0.0"), TakerFeeRate: MustParseDecimal("0.0")},
+				PairETCJPY: {MakerFeeRate: MustParseDecimal("0.05"), TakerFeeRate: MustParseDecimal("0.1")},
+			},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("GetAccounts returns an error if client does not set credentials", func(t *testing.T) {
+		client, err := NewClient(WithBaseURL("https://example.com"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = client.GetAccounts(context.Background()); !errors.Is(err, ErrNoCredentials) {
+			t.Errorf("error is not ErrNoCredentials: %v", err)
+		}
+	})
+}
+
+func TestGetAccountsResponse_EstimateFee(t *testing.T) {
+	t.Parallel()
+
+	accounts := &GetAccountsResponse{
+		ExchangeFees: map[Pair]ExchangeFee{
+			PairETCJPY: {MakerFeeRate: MustParseDecimal("0.05"), TakerFeeRate: MustParseDecimal("0.1")},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		liquidity Liquidity
+		want      string
+	}{
+		{name: "taker", liquidity: LiquidityTaker, want: "3.0"},
+		{name: "maker", liquidity: LiquidityMaker, want: "1.5"},
+		{name: "unknown liquidity is a taker", liquidity: "", want: "3.0"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := accounts.EstimateFee(PairETCJPY, tt.liquidity, MustParseDecimal("3000"), MustParseDecimal("1"))
+			if !ok {
+				t.Fatal("fee of etc_jpy must exist")
+			}
+			if !got.Equal(MustParseDecimal(tt.want)) {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if _, ok := accounts.EstimateFee(PairBTCJPY, LiquidityTaker, MustParseDecimal("1"), MustParseDecimal("1")); ok {
+		t.Error("fee of btc_jpy must not exist")
+	}
+}
@@ -1,38 +1,157 @@
 package coincheck
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"net/http"
+	"strings"
 )
 
+// Balance is the balance of a currency.
+type Balance struct {
+	// Available is the balance that you can use (e.g. "jpy", "btc").
+	Available Decimal
+	// Reserved is the amount for unsettled orders (e.g. "jpy_reserved").
+	Reserved Decimal
+	// LendInUse is the amount you are applying for lending (e.g. "jpy_lend_in_use").
+	LendInUse Decimal
+	// Lent is the lending amount (e.g. "jpy_lent").
+	Lent Decimal
+	// Debt is the borrowing amount (e.g. "jpy_debt").
+	Debt Decimal
+	// Tsumitate is the reserving amount (e.g. "jpy_tsumitate").
+	Tsumitate Decimal
+}
+
+// Total returns the amount you hold: Available + Reserved + LendInUse + Lent + Tsumitate.
+// Debt is not subtracted. Use Net for it.
+func (b Balance) Total() Decimal {
+	return b.Available.Add(b.Reserved).Add(b.LendInUse).Add(b.Lent).Add(b.Tsumitate)
+}
+
+// Net returns Total - Debt.
+func (b Balance) Net() Decimal {
+	return b.Total().Sub(b.Debt)
+}
+
+// set sets the value of the field of the balance. e.g. "reserved" for "jpy_reserved", "" for "jpy".
+// It returns false if the field is unknown.
+func (b *Balance) set(field string, value Decimal) bool {
+	switch field {
+	case "":
+		b.Available = value
+	case "reserved":
+		b.Reserved = value
+	case "lend_in_use":
+		b.LendInUse = value
+	case "lent":
+		b.Lent = value
+	case "debt":
+		b.Debt = value
+	case "tsumitate":
+		b.Tsumitate = value
+	default:
+		return false
+	}
+	return true
+}
+
 // GetAccountsBalanceResponse represents the response from the GetAccountsBalance method.
 type GetAccountsBalanceResponse struct {
 	// Success is true if the request was successful.
 	Success bool `json:"success"`
 	// JPY is the balance of JPY.
-	JPY string `json:"jpy"`
+	JPY Decimal `json:"jpy"`
 	// BTC is the balance of BTC.
-	BTC string `json:"btc"`
+	BTC Decimal `json:"btc"`
 	// JPYReserved is amount of JPY for unsettled buying order
-	JPYReserved string `json:"jpy_reserved"`
+	JPYReserved Decimal `json:"jpy_reserved"`
 	// BTCReserved is amount of BTC for unsettled selling order
-	BTCReserved string `json:"btc_reserved"`
+	BTCReserved Decimal `json:"btc_reserved"`
 	// JPYLendInUse is JPY amount you are applying for lending (We don't allow you to loan JPY.)
-	JPYLendInUse string `json:"jpy_lend_in_use"`
+	JPYLendInUse Decimal `json:"jpy_lend_in_use"`
 	// BTCLendInUse is BTC Amount you are applying for lending (We don't allow you to loan BTC.)
-	BTCLendInUse string `json:"btc_lend_in_use"`
+	BTCLendInUse Decimal `json:"btc_lend_in_use"`
 	// JPYLent is JPY lending amount (Currently, we don't allow you to loan JPY.)
-	JPYLent string `json:"jpy_lent"`
+	JPYLent Decimal `json:"jpy_lent"`
 	// BTCLent is BTC lending amount (Currently, we don't allow you to loan BTC.)
-	BTCLent string `json:"btc_lent"`
+	BTCLent Decimal `json:"btc_lent"`
 	// JPYDebt is JPY borrowing amount
-	JPYDebt string `json:"jpy_debt"`
+	JPYDebt Decimal `json:"jpy_debt"`
 	// BTCDebt is BTC borrowing amount
-	BTCDebt string `json:"btc_debt"`
+	BTCDebt Decimal `json:"btc_debt"`
 	// JPYTsumitate is JPY reserving amount
-	JPYTsumitate string `json:"jpy_tsumitate"`
+	JPYTsumitate Decimal `json:"jpy_tsumitate"`
 	// BTCTsumitate is BTC reserving amount
-	BTCTsumitate string `json:"btc_tsumitate"`
+	BTCTsumitate Decimal `json:"btc_tsumitate"`
+	// Balances is the balances of all currencies in the response, including JPY and BTC.
+	Balances map[Currency]Balance `json:"-"`
+}
+
+// UnmarshalJSON decodes the response. The fields of all currencies (e.g. "eth", "eth_reserved")
+// are decoded into Balances, in addition to the JPY and BTC fields.
+// The other fields (e.g. "error" of an error response, "updated_at") are ignored.
+func (r *GetAccountsBalanceResponse) UnmarshalJSON(b []byte) error {
+	type alias GetAccountsBalanceResponse
+	var out alias
+	if err := json.Unmarshal(b, &out); err != nil {
+		return withPrefixError(err)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return withPrefixError(err)
+	}
+	out.Balances = make(map[Currency]Balance)
+	for key, raw := range fields {
+		if !isBalanceValue(raw) {
+			continue // e.g. "success"
+		}
+		currency, field, _ := strings.Cut(key, "_")
+		if currency == "" || !isBalanceField(field) {
+			continue
+		}
+		var value Decimal
+		if err := value.UnmarshalJSON(raw); err != nil {
+			continue // not a balance, e.g. "error": "invalid authentication"
+		}
+		balance := out.Balances[Currency(currency)]
+		balance.set(field, value)
+		out.Balances[Currency(currency)] = balance
+	}
+	*r = GetAccountsBalanceResponse(out)
+	return nil
+}
+
+// isBalanceField returns true if field is a field of Balance. e.g. "reserved" for "jpy_reserved".
+func isBalanceField(field string) bool {
+	var b Balance
+	return b.set(field, Decimal{})
+}
+
+// isBalanceValue returns true if raw is a JSON string or number.
+func isBalanceValue(raw json.RawMessage) bool {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return false
+	}
+	return raw[0] == '"' || raw[0] == '-' || (raw[0] >= '0' && raw[0] <= '9')
+}
+
+// Balance returns the balance of the currency. It returns false if the currency is not in the response.
+func (r *GetAccountsBalanceResponse) Balance(currency Currency) (Balance, bool) {
+	balance, ok := r.Balances[currency]
+	return balance, ok
+}
+
+// Totals returns Balance.Total of each currency.
+func (r *GetAccountsBalanceResponse) Totals() map[Currency]Decimal {
+	totals := make(map[Currency]Decimal, len(r.Balances))
+	for currency, balance := range r.Balances {
+		totals[currency] = balance.Total()
+	}
+	return totals
 }
 
 // GetAccountsBalance returns the balance of the account.
@@ -26,18 +26,18 @@ func TestClient_GetBalance(t *testing.T) {
 
 			result := GetAccountsBalanceResponse{
 				Success:      true,
-				JPY:          "0.8401",
-				BTC:          "7.75052654",
-				JPYReserved:  "3000.0",
-				BTCReserved:  "3.5002",
-				JPYLendInUse: "1.1",
-				BTCLendInUse: "0.3",
-				JPYLent:      "0",
-				BTCLent:      "1.2",
-				JPYDebt:      "0",
-				BTCDebt:      "0",
-				JPYTsumitate: "10000.0",
-				BTCTsumitate: "0.43034",
+				JPY:          MustParseDecimal("0.8401"),
+				BTC:          MustParseDecimal("7.75052654"),
+				JPYReserved:  MustParseDecimal("3000.0"),
+				BTCReserved:  MustParseDecimal("3.5002"),
+				JPYLendInUse: MustParseDecimal("1.1"),
+				BTCLendInUse: MustParseDecimal("0.3"),
+				JPYLent:      MustParseDecimal("0"),
+				BTCLent:      MustParseDecimal("1.2"),
+				JPYDebt:      MustParseDecimal("0"),
+				BTCDebt:      MustParseDecimal("0"),
+				JPYTsumitate: MustParseDecimal("10000.0"),
+				BTCTsumitate: MustParseDecimal("0.43034"),
 			}
 			if err := json.NewEncoder(w).Encode(result); err != nil {
 				t.Fatal(err)
@@ -59,24 +59,140 @@ func TestClient_GetBalance(t *testing.T) {
 
 		want := &GetAccountsBalanceResponse{
 			Success:      true,
-			JPY:          "0.8401",
-			BTC:          "7.75052654",
-			JPYReserved:  "3000.0",
-			BTCReserved:  "3.5002",
-			JPYLendInUse: "1.1",
-			BTCLendInUse: "0.3",
-			JPYLent:      "0",
-			BTCLent:      "1.2",
-			JPYDebt:      "0",
-			BTCDebt:      "0",
-			JPYTsumitate: "10000.0",
-			BTCTsumitate: "0.43034",
+			JPY:          MustParseDecimal("0.8401"),
+			BTC:          MustParseDecimal("7.75052654"),
+			JPYReserved:  MustParseDecimal("3000.0"),
+			BTCReserved:  MustParseDecimal("3.5002"),
+			JPYLendInUse: MustParseDecimal("1.1"),
+			BTCLendInUse: MustParseDecimal("0.3"),
+			JPYLent:      MustParseDecimal("0"),
+			BTCLent:      MustParseDecimal("1.2"),
+			JPYDebt:      MustParseDecimal("0"),
+			BTCDebt:      MustParseDecimal("0"),
+			JPYTsumitate: MustParseDecimal("10000.0"),
+			BTCTsumitate: MustParseDecimal("0.43034"),
+			Balances: map[Currency]Balance{
+				CurrencyJPY: {
+					Available: MustParseDecimal("0.8401"),
+					Reserved:  MustParseDecimal("3000.0"),
+					LendInUse: MustParseDecimal("1.1"),
+					Lent:      MustParseDecimal("0"),
+					Debt:      MustParseDecimal("0"),
+					Tsumitate: MustParseDecimal("10000.0"),
+				},
+				CurrencyBTC: {
+					Available: MustParseDecimal("7.75052654"),
+					Reserved:  MustParseDecimal("3.5002"),
+					LendInUse: MustParseDecimal("0.3"),
+					Lent:      MustParseDecimal("1.2"),
+					Debt:      MustParseDecimal("0"),
+					Tsumitate: MustParseDecimal("0.43034"),
+				},
+			},
 		}
 		if diff := cmp.Diff(want, got); diff != "" {
 			printDiff(t, diff)
 		}
 	})
 
+	t.Run("GetBalance returns the balances of all currencies", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Write([]byte(`{
+				"success": true,
+				"jpy": "1000.0",
+				"btc": "0.1",
+				"eth": "2.5",
+				"eth_reserved": "0.5",
+				"eth_lend_in_use": "0",
+				"eth_lent": "1.0",
+				"eth_debt": "0.2",
+				"eth_tsumitate": "0.1",
+				"bril": "100",
+				"bril_unknown_field": "9",
+				"updated_at": "2015-01-10T05:55:38.000Z",
+				"status": "active"
+			}`)) //nolint: errcheck // ignore error
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := client.GetAccountsBalance(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !got.JPY.Equal(MustParseDecimal("1000")) || !got.BTC.Equal(MustParseDecimal("0.1")) {
+			t.Errorf("JPY and BTC fields are not decoded: %s, %s", got.JPY, got.BTC)
+		}
+
+		wantETH := Balance{
+			Available: MustParseDecimal("2.5"),
+			Reserved:  MustParseDecimal("0.5"),
+			LendInUse: MustParseDecimal("0"),
+			Lent:      MustParseDecimal("1.0"),
+			Debt:      MustParseDecimal("0.2"),
+			Tsumitate: MustParseDecimal("0.1"),
+		}
+		eth, ok := got.Balance(CurrencyETH)
+		if !ok {
+			t.Fatal("balance of eth must exist")
+		}
+		if diff := cmp.Diff(wantETH, eth); diff != "" {
+			printDiff(t, diff)
+		}
+		if !eth.Total().Equal(MustParseDecimal("4.1")) {
+			t.Errorf("total of eth: got %s, want 4.1", eth.Total())
+		}
+		if !eth.Net().Equal(MustParseDecimal("3.9")) {
+			t.Errorf("net of eth: got %s, want 3.9", eth.Net())
+		}
+
+		totals := got.Totals()
+		if len(totals) != 4 {
+			t.Errorf("totals must have 4 currencies: %v", totals)
+		}
+		if !totals[CurrencyBril].Equal(MustParseDecimal("100")) {
+			t.Errorf("total of bril: got %s, want 100", totals[CurrencyBril])
+		}
+		if _, ok := got.Balance(CurrencyDai); ok {
+			t.Error("balance of dai must not exist")
+		}
+	})
+
+	t.Run("GetBalance returns the raw envelope of an error response if WithRawEnvelope is set", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Write([]byte(`{"success":false,"error":"invalid authentication"}`)) //nolint: errcheck // ignore error
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+			WithRawEnvelope(),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := client.GetAccountsBalance(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Success {
+			t.Error("Success must be false")
+		}
+		if len(got.Balances) != 0 {
+			t.Errorf("balances must be empty: %v", got.Balances)
+		}
+	})
+
 	t.Run("GetBalance returns an error if the server returns an error", func(t *testing.T) {
 		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 			w.WriteHeader(http.StatusInternalServerError)
@@ -0,0 +1,303 @@
+package coincheck
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// minCandleInterval is the minimum interval of the candles.
+	minCandleInterval = time.Second
+	// maxCandleInterval is the maximum interval of the candles.
+	maxCandleInterval = 24 * time.Hour
+)
+
+// Candle is an OHLCV bar built from trades.
+type Candle struct {
+	// Pair is the pair of the currency.
+	Pair Pair
+	// Start is the start time of the bar (inclusive).
+	Start time.Time
+	// End is the end time of the bar (exclusive).
+	End time.Time
+	// Open is the rate of the first trade.
+	Open Decimal
+	// High is the highest rate.
+	High Decimal
+	// Low is the lowest rate.
+	Low Decimal
+	// Close is the rate of the last trade.
+	Close Decimal
+	// Volume is the total amount of the trades.
+	Volume Decimal
+	// BuyVolume is the total amount of the trades whose OrderType is buy (the taker bought).
+	BuyVolume Decimal
+	// SellVolume is the total amount of the trades whose OrderType is sell (the taker sold).
+	SellVolume Decimal
+	// Notional is the total Rate * Amount of the trades.
+	Notional Decimal
+	// VWAP is the volume weighted average price, Notional / Volume.
+	// It's rounded to 8 digits after the decimal point, and it's zero if there is no trade.
+	VWAP Decimal
+	// TradeCount is the number of the trades.
+	TradeCount int
+}
+
+// CandleBuilderInput represents the input parameter for NewCandleBuilder.
+type CandleBuilderInput struct {
+	// Pair is the pair of the currency. If it's set, the trades of other pairs are ignored.
+	// If it's empty, the bars are built for each pair separately.
+	Pair Pair
+	// Interval is the length of the bars, from 1 second to 24 hours. The bars are aligned to UTC.
+	// e.g. With 1 hour, the bars start at 00:00, 01:00, ... UTC.
+	Interval time.Duration
+	// AllowedLateness is how long a bar is kept open after its end, to accept trades that arrive out of order.
+	// A bar is closed when a trade later than End + AllowedLateness is added, or by AdvanceTo or Flush.
+	AllowedLateness time.Duration
+	// FillGaps emits a bar with no trade for each interval without trades, between two bars.
+	// Its Open, High, Low and Close are the Close of the previous bar.
+	FillGaps bool
+	// OnClose is called with each bar when it's closed, in the order of Start.
+	// It's called from the goroutine that calls Add, AdvanceTo or Flush.
+	OnClose func(Candle)
+}
+
+// candleKey is the key of an open bar.
+type candleKey struct {
+	pair Pair
+	// start is the start time in Unix nanoseconds.
+	start int64
+}
+
+// candleState is an open bar.
+type candleState struct {
+	candle Candle
+	// ids is the trade IDs in the bar to detect duplicates.
+	ids map[int]struct{}
+	// first and last are the first and the last trades in the bar.
+	first, last Trade
+}
+
+// CandleBuilder builds OHLCV bars from trades (e.g. GetTrades, BackfillTrades or PublicStream).
+// The trades can be added in any order. The duplicated trades (same ID) are ignored.
+//
+// All methods are safe for concurrent use.
+type CandleBuilder struct {
+	input CandleBuilderInput
+
+	mu sync.Mutex
+	// open is the open bars keyed by the pair and the start time.
+	open map[candleKey]*candleState
+	// closedUntil is the end of the last closed bar of each pair. The trades before it are too late.
+	closedUntil map[Pair]time.Time
+	// watermark is the latest trade time of all pairs.
+	watermark time.Time
+	// lastClose is the last closed bar of each pair. It's used by FillGaps.
+	lastClose map[Pair]Candle
+}
+
+// NewCandleBuilder returns a new CandleBuilder.
+// It returns ErrInvalidCandleInterval if Interval is not between 1 second and 24 hours.
+func NewCandleBuilder(input CandleBuilderInput) (*CandleBuilder, error) {
+	if input.Interval < minCandleInterval || input.Interval > maxCandleInterval {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCandleInterval, input.Interval)
+	}
+	if input.AllowedLateness < 0 {
+		input.AllowedLateness = 0
+	}
+	return &CandleBuilder{
+		input:       input,
+		open:        map[candleKey]*candleState{},
+		closedUntil: map[Pair]time.Time{},
+		lastClose:   map[Pair]Candle{},
+	}, nil
+}
+
+// Add adds the trade to its bar, and closes the bars that end before the trade (see AllowedLateness).
+// It returns false if the trade is ignored because it's a duplicate, its bar is already closed,
+// or it's a trade of another pair.
+func (b *CandleBuilder) Add(trade Trade) bool {
+	if b.input.Pair != "" && trade.Pair != b.input.Pair {
+		return false
+	}
+
+	b.mu.Lock()
+	added := b.addLocked(trade)
+	var closed []Candle
+	if added && trade.CreatedAt.After(b.watermark) {
+		b.watermark = trade.CreatedAt.Time
+		closed = b.closeLocked(b.watermark, false)
+	}
+	b.mu.Unlock()
+
+	b.emit(closed)
+	return added
+}
+
+// AdvanceTo closes the bars that end before now - AllowedLateness.
+// Call it periodically to close the bars when there is no trade (e.g. with a time.Ticker).
+func (b *CandleBuilder) AdvanceTo(now time.Time) {
+	b.mu.Lock()
+	closed := b.closeLocked(now, false)
+	b.mu.Unlock()
+
+	b.emit(closed)
+}
+
+// Flush closes all open bars. e.g. Call it after adding all trades of a backfill.
+func (b *CandleBuilder) Flush() {
+	b.mu.Lock()
+	closed := b.closeLocked(time.Time{}, true)
+	b.mu.Unlock()
+
+	b.emit(closed)
+}
+
+// Current returns the latest open bar. It returns false if there is no open bar.
+// If CandleBuilderInput.Pair is empty, it's the latest open bar of any pair.
+func (b *CandleBuilder) Current() (Candle, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var latest *candleState
+	for _, state := range b.open {
+		if latest == nil || state.candle.Start.After(latest.candle.Start) {
+			latest = state
+		}
+	}
+	if latest == nil {
+		return Candle{}, false
+	}
+	return latest.snapshot(), true
+}
+
+// addLocked adds the trade to its bar. b.mu must be held.
+func (b *CandleBuilder) addLocked(trade Trade) bool {
+	start := trade.CreatedAt.Truncate(b.input.Interval)
+	if start.Before(b.closedUntil[trade.Pair]) {
+		return false
+	}
+
+	key := candleKey{pair: trade.Pair, start: start.UnixNano()}
+	state, ok := b.open[key]
+	if !ok {
+		state = &candleState{
+			candle: Candle{
+				Pair:  trade.Pair,
+				Start: start,
+				End:   start.Add(b.input.Interval),
+			},
+			ids: map[int]struct{}{},
+		}
+		b.open[key] = state
+	}
+	if _, dup := state.ids[trade.ID]; dup {
+		return false
+	}
+	state.ids[trade.ID] = struct{}{}
+	state.add(trade)
+	return true
+}
+
+// closeLocked closes the bars that end before limit - AllowedLateness, or all bars if all is true,
+// and returns them in the order of Start (and Pair for the bars at the same time). b.mu must be held.
+func (b *CandleBuilder) closeLocked(limit time.Time, all bool) []Candle {
+	keys := make([]candleKey, 0, len(b.open))
+	for key, state := range b.open {
+		if all || !state.candle.End.Add(b.input.AllowedLateness).After(limit) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].start != keys[j].start {
+			return keys[i].start < keys[j].start
+		}
+		return keys[i].pair < keys[j].pair
+	})
+
+	closed := make([]Candle, 0, len(keys))
+	for _, key := range keys {
+		candle := b.open[key].snapshot()
+		delete(b.open, key)
+
+		if last, ok := b.lastClose[key.pair]; ok && b.input.FillGaps {
+			for start := last.End; start.Before(candle.Start); start = start.Add(b.input.Interval) {
+				closed = append(closed, Candle{
+					Pair:  candle.Pair,
+					Start: start,
+					End:   start.Add(b.input.Interval),
+					Open:  last.Close,
+					High:  last.Close,
+					Low:   last.Close,
+					Close: last.Close,
+				})
+			}
+		}
+		closed = append(closed, candle)
+		b.lastClose[key.pair] = candle
+		b.closedUntil[key.pair] = candle.End
+	}
+	return closed
+}
+
+// emit calls OnClose with the closed bars.
+func (b *CandleBuilder) emit(closed []Candle) {
+	if b.input.OnClose == nil {
+		return
+	}
+	for _, candle := range closed {
+		b.input.OnClose(candle)
+	}
+}
+
+// add adds the trade to the bar.
+func (s *candleState) add(trade Trade) {
+	c := &s.candle
+	if c.TradeCount == 0 {
+		s.first, s.last = trade, trade
+		c.High, c.Low = trade.Rate, trade.Rate
+	} else {
+		if tradeBefore(trade, s.first) {
+			s.first = trade
+		}
+		if tradeBefore(s.last, trade) {
+			s.last = trade
+		}
+		if trade.Rate.GreaterThan(c.High) {
+			c.High = trade.Rate
+		}
+		if trade.Rate.LessThan(c.Low) {
+			c.Low = trade.Rate
+		}
+	}
+	c.Open, c.Close = s.first.Rate, s.last.Rate
+
+	c.Volume = c.Volume.Add(trade.Amount)
+	switch trade.OrderType {
+	case OrderTypeBuy, OrderTypeMarketBuy:
+		c.BuyVolume = c.BuyVolume.Add(trade.Amount)
+	case OrderTypeSell, OrderTypeMarketSell:
+		c.SellVolume = c.SellVolume.Add(trade.Amount)
+	}
+	c.Notional = c.Notional.Add(trade.Rate.Mul(trade.Amount))
+	c.TradeCount++
+}
+
+// snapshot returns a copy of the bar with VWAP.
+func (s *candleState) snapshot() Candle {
+	c := s.candle
+	if !c.Volume.IsZero() {
+		c.VWAP = c.Notional.Div(c.Volume, estimatePrecision)
+	}
+	return c
+}
+
+// tradeBefore returns true if a is before b. The trades at the same time are ordered by ID.
+func tradeBefore(a, b Trade) bool {
+	if !a.CreatedAt.EqualTime(b.CreatedAt) {
+		return a.CreatedAt.Before(b.CreatedAt.Time)
+	}
+	return a.ID < b.ID
+}
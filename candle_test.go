@@ -0,0 +1,280 @@
+package coincheck
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// candleBase is the start time of the trades in the candle tests.
+func candleBase() time.Time {
+	return time.Date(2024, 8, 3, 5, 10, 0, 0, time.UTC)
+}
+
+// newCandleTrade returns a btc_jpy trade at candleBase + sec seconds.
+func newCandleTrade(id, sec int, rate, amount string, orderType OrderType) Trade {
+	return Trade{
+		ID:        id,
+		Rate:      MustParseDecimal(rate),
+		Amount:    MustParseDecimal(amount),
+		Pair:      PairBTCJPY,
+		OrderType: orderType,
+		CreatedAt: NewTime(candleBase().Add(time.Duration(sec) * time.Second)),
+	}
+}
+
+func TestCandleBuilder(t *testing.T) {
+	t.Parallel()
+
+	t.Run("CandleBuilder builds a bar from out-of-order and duplicated trades", func(t *testing.T) {
+		t.Parallel()
+
+		var closed []Candle
+		b, err := NewCandleBuilder(CandleBuilderInput{
+			Pair:     PairBTCJPY,
+			Interval: time.Minute,
+			OnClose: func(c Candle) {
+				closed = append(closed, c)
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		trades := []struct {
+			trade Trade
+			want  bool
+		}{
+			{trade: newCandleTrade(3, 30, "101", "1", OrderTypeSell), want: true},
+			{trade: newCandleTrade(1, 10, "100", "2", OrderTypeBuy), want: true},
+			{trade: newCandleTrade(4, 50, "99", "1", OrderTypeBuy), want: true},
+			{trade: newCandleTrade(2, 30, "103", "1", OrderTypeBuy), want: true},
+			{trade: newCandleTrade(3, 30, "101", "1", OrderTypeSell), want: false},
+			{trade: Trade{ID: 5, Pair: PairETCJPY, CreatedAt: NewTime(candleBase())}, want: false},
+		}
+		for _, tt := range trades {
+			if got := b.Add(tt.trade); got != tt.want {
+				t.Errorf("Add(%d): got %v, want %v", tt.trade.ID, got, tt.want)
+			}
+		}
+		if len(closed) != 0 {
+			t.Fatalf("the bar must be open: %v", closed)
+		}
+
+		current, ok := b.Current()
+		if !ok {
+			t.Fatal("the bar must be open")
+		}
+		if diff := cmp.Diff(4, current.TradeCount); diff != "" {
+			printDiff(t, diff)
+		}
+
+		// The trade in the next bar closes the bar.
+		if !b.Add(newCandleTrade(6, 60, "98", "1", OrderTypeSell)) {
+			t.Fatal("Add must return true")
+		}
+		// The trade in the closed bar is too late.
+		if b.Add(newCandleTrade(7, 59, "97", "1", OrderTypeSell)) {
+			t.Error("Add must return false for the closed bar")
+		}
+
+		want := []Candle{
+			{
+				Pair:       PairBTCJPY,
+				Start:      candleBase(),
+				End:        candleBase().Add(time.Minute),
+				Open:       MustParseDecimal("100"),
+				High:       MustParseDecimal("103"),
+				Low:        MustParseDecimal("99"),
+				Close:      MustParseDecimal("99"),
+				Volume:     MustParseDecimal("5"),
+				BuyVolume:  MustParseDecimal("4"),
+				SellVolume: MustParseDecimal("1"),
+				Notional:   MustParseDecimal("503"),
+				VWAP:       MustParseDecimal("100.6"),
+				TradeCount: 4,
+			},
+		}
+		if diff := cmp.Diff(want, closed); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("The last trade at the same time is decided by the trade ID", func(t *testing.T) {
+		t.Parallel()
+
+		b, err := NewCandleBuilder(CandleBuilderInput{Interval: time.Second})
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.Add(newCandleTrade(11, 0, "200", "1", OrderTypeBuy))
+		b.Add(newCandleTrade(10, 0, "100", "1", OrderTypeBuy))
+		b.Add(newCandleTrade(12, 0, "300", "1", OrderTypeBuy))
+
+		got, _ := b.Current()
+		if diff := cmp.Diff("100", got.Open.String()); diff != "" {
+			printDiff(t, diff)
+		}
+		if diff := cmp.Diff("300", got.Close.String()); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("AllowedLateness keeps the bar open for the late trades", func(t *testing.T) {
+		t.Parallel()
+
+		var closed []Candle
+		b, err := NewCandleBuilder(CandleBuilderInput{
+			Interval:        time.Minute,
+			AllowedLateness: 10 * time.Second,
+			OnClose: func(c Candle) {
+				closed = append(closed, c)
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b.Add(newCandleTrade(1, 0, "100", "1", OrderTypeBuy))
+		b.Add(newCandleTrade(3, 65, "101", "1", OrderTypeBuy))
+		if !b.Add(newCandleTrade(2, 59, "102", "1", OrderTypeBuy)) {
+			t.Fatal("the late trade must be accepted")
+		}
+		if len(closed) != 0 {
+			t.Fatalf("the bar must be open: %v", closed)
+		}
+
+		b.AdvanceTo(candleBase().Add(70 * time.Second))
+		if len(closed) != 1 {
+			t.Fatalf("the first bar must be closed: %v", closed)
+		}
+		if diff := cmp.Diff(2, closed[0].TradeCount); diff != "" {
+			printDiff(t, diff)
+		}
+		if diff := cmp.Diff("102", closed[0].Close.String()); diff != "" {
+			printDiff(t, diff)
+		}
+
+		b.Flush()
+		if len(closed) != 2 {
+			t.Fatalf("the second bar must be closed by Flush: %v", closed)
+		}
+		if _, ok := b.Current(); ok {
+			t.Error("there must be no open bar after Flush")
+		}
+	})
+
+	t.Run("FillGaps emits the bars without trades", func(t *testing.T) {
+		t.Parallel()
+
+		var closed []Candle
+		b, err := NewCandleBuilder(CandleBuilderInput{
+			Interval: time.Minute,
+			FillGaps: true,
+			OnClose: func(c Candle) {
+				closed = append(closed, c)
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b.Add(newCandleTrade(1, 0, "100", "1", OrderTypeBuy))
+		b.Add(newCandleTrade(2, 180, "105", "1", OrderTypeSell))
+		b.Flush()
+
+		var starts []time.Time
+		for _, c := range closed {
+			starts = append(starts, c.Start)
+		}
+		wantStarts := []time.Time{
+			candleBase(),
+			candleBase().Add(time.Minute),
+			candleBase().Add(2 * time.Minute),
+			candleBase().Add(3 * time.Minute),
+		}
+		if diff := cmp.Diff(wantStarts, starts); diff != "" {
+			printDiff(t, diff)
+		}
+
+		wantGap := Candle{
+			Pair:  PairBTCJPY,
+			Start: candleBase().Add(time.Minute),
+			End:   candleBase().Add(2 * time.Minute),
+			Open:  MustParseDecimal("100"),
+			High:  MustParseDecimal("100"),
+			Low:   MustParseDecimal("100"),
+			Close: MustParseDecimal("100"),
+		}
+		if diff := cmp.Diff(wantGap, closed[1]); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("CandleBuilder builds the bars of each pair separately if Pair is empty", func(t *testing.T) {
+		t.Parallel()
+
+		var closed []Candle
+		b, err := NewCandleBuilder(CandleBuilderInput{
+			Interval: time.Minute,
+			OnClose: func(c Candle) {
+				closed = append(closed, c)
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		etc := newCandleTrade(2, 20, "3000", "5", OrderTypeSell)
+		etc.Pair = PairETCJPY
+		b.Add(newCandleTrade(1, 10, "9000000", "0.1", OrderTypeBuy))
+		b.Add(etc)
+		b.Flush()
+
+		want := []Candle{
+			{
+				Pair:       PairBTCJPY,
+				Start:      candleBase(),
+				End:        candleBase().Add(time.Minute),
+				Open:       MustParseDecimal("9000000"),
+				High:       MustParseDecimal("9000000"),
+				Low:        MustParseDecimal("9000000"),
+				Close:      MustParseDecimal("9000000"),
+				Volume:     MustParseDecimal("0.1"),
+				BuyVolume:  MustParseDecimal("0.1"),
+				Notional:   MustParseDecimal("900000"),
+				VWAP:       MustParseDecimal("9000000"),
+				TradeCount: 1,
+			},
+			{
+				Pair:       PairETCJPY,
+				Start:      candleBase(),
+				End:        candleBase().Add(time.Minute),
+				Open:       MustParseDecimal("3000"),
+				High:       MustParseDecimal("3000"),
+				Low:        MustParseDecimal("3000"),
+				Close:      MustParseDecimal("3000"),
+				Volume:     MustParseDecimal("5"),
+				SellVolume: MustParseDecimal("5"),
+				Notional:   MustParseDecimal("15000"),
+				VWAP:       MustParseDecimal("3000"),
+				TradeCount: 1,
+			},
+		}
+		if diff := cmp.Diff(want, closed); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("NewCandleBuilder returns an error for an invalid interval", func(t *testing.T) {
+		t.Parallel()
+
+		for _, interval := range []time.Duration{0, time.Millisecond, 25 * time.Hour} {
+			if _, err := NewCandleBuilder(CandleBuilderInput{Interval: interval}); !errors.Is(err, ErrInvalidCandleInterval) {
+				t.Errorf("%s: error is not ErrInvalidCandleInterval: %v", interval, err)
+			}
+		}
+	})
+}
@@ -8,17 +8,27 @@
 package coincheck
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
 )
 
 const (
 	// BaseURL is the base URL for the coincheck API.
 	BaseURL = "https://coincheck.com"
+	// PublicWebSocketURL is the URL of the coincheck public WebSocket API.
+	PublicWebSocketURL = "wss://ws-api.coincheck.com/"
+	// PrivateWebSocketURL is the URL of the coincheck private WebSocket API.
+	PrivateWebSocketURL = "wss://stream.coincheck.com"
 )
 
 // Client represents a coincheck client.
@@ -29,12 +39,25 @@ type Client struct {
 	baseURL *url.URL
 	// credentials is the credentials used to authenticate with the coincheck API.
 	credentials *credentials
+	// nonceSource generates the ACCESS-NONCE value for signed requests.
+	nonceSource NonceSource
+	// rawEnvelope is true if responses with "success": false are returned as they are.
+	rawEnvelope bool
+	// publicWebSocketURL is the URL of the coincheck public WebSocket API.
+	publicWebSocketURL *url.URL
+	// privateWebSocketURL is the URL of the coincheck private WebSocket API.
+	privateWebSocketURL *url.URL
+	// dialer is the WebSocket dialer used by the streaming clients.
+	dialer *websocket.Dialer
+	// sendMoneyConfirmer approves each crypto transfer before it's sent. SendMoney fails if it's nil.
+	sendMoneyConfirmer SendMoneyConfirmer
 }
 
 // NewClient returns a new coincheck client.
 func NewClient(opts ...Option) (*Client, error) {
 	c := &Client{
-		client: http.DefaultClient,
+		client:      http.DefaultClient,
+		nonceSource: NewMonotonicNonceSource(),
 	}
 
 	baseURL, err := url.Parse(BaseURL)
@@ -43,6 +66,21 @@ func NewClient(opts ...Option) (*Client, error) {
 	}
 	c.baseURL = baseURL
 
+	publicWebSocketURL, err := url.Parse(PublicWebSocketURL)
+	if err != nil {
+		return nil, withPrefixError(err)
+	}
+	c.publicWebSocketURL = publicWebSocketURL
+
+	privateWebSocketURL, err := url.Parse(PrivateWebSocketURL)
+	if err != nil {
+		return nil, withPrefixError(err)
+	}
+	c.privateWebSocketURL = privateWebSocketURL
+
+	dialer := *websocket.DefaultDialer
+	c.dialer = &dialer
+
 	for _, opt := range opts {
 		if err := opt(c); err != nil {
 			return nil, err
@@ -59,13 +97,9 @@ func (c *Client) hasCredentials() bool {
 // setAuthHeaders sets the authentication headers to the request.
 // If you use Private API, you need to get your API key and API secret from the coincheck website.
 func (c *Client) setAuthHeaders(req *http.Request, body string) error {
-	if !c.hasCredentials() {
-		return ErrNoCredentials
-	}
-
-	headers, err := c.credentials.generateRequestHeaders(req.URL, body)
+	headers, err := c.signRequest(req.URL, body)
 	if err != nil {
-		return withPrefixError(err)
+		return err
 	}
 	req.Header.Set("ACCESS-KEY", headers.AccessKey)
 	req.Header.Set("ACCESS-NONCE", headers.AccessNonce)
@@ -73,11 +107,32 @@ func (c *Client) setAuthHeaders(req *http.Request, body string) error {
 	return nil
 }
 
+// signRequest signs the request URL and body with a new nonce.
+func (c *Client) signRequest(requestURL *url.URL, body string) (*requestHeaderParam, error) {
+	if !c.hasCredentials() {
+		return nil, ErrNoCredentials
+	}
+
+	nonce, err := c.nonceSource.Nonce()
+	if err != nil {
+		if errors.Is(err, ErrNonceSource) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %w", ErrNonceSource, err)
+	}
+
+	headers, err := c.credentials.generateRequestHeaders(nonce, requestURL, body)
+	if err != nil {
+		return nil, withPrefixError(err)
+	}
+	return headers, nil
+}
+
 // createRequestInput represents the input parameters for createRequest.
 type createRequestInput struct {
 	method     string            // HTTP method (e.g. GET, POST)
 	path       string            // API path (e.g. /api/orders)
-	body       io.Reader         // Request body. If you don't need it, set nil.
+	body       any               // Request body. It's serialized to JSON once, then signed and sent. If you don't need it, set nil.
 	queryParam map[string]string // Query parameters (e.g. {"pair": "btc_jpy"}) If you don't need it, set nil.
 	private    bool              // If true, it's a private API.
 }
@@ -102,7 +157,14 @@ func (c *Client) createRequest(ctx context.Context, input createRequestInput) (*
 		endpoint.RawQuery = q.Encode()
 	}
 
-	req, err := http.NewRequestWithContext(ctx, input.method, endpoint.String(), input.body)
+	var body []byte
+	if input.body != nil {
+		if body, err = json.Marshal(input.body); err != nil {
+			return nil, withPrefixError(err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, input.method, endpoint.String(), bytes.NewReader(body))
 	if err != nil {
 		return nil, withPrefixError(err)
 	}
@@ -110,7 +172,9 @@ func (c *Client) createRequest(ctx context.Context, input createRequestInput) (*
 	req.Header.Add("content-type", "application/json")
 	req.Header.Add("cache-control", "no-cache")
 	if input.private {
-		if err := c.setAuthHeaders(req, ""); err != nil {
+		// The signature message is nonce + URL + body, so the signed body must be
+		// byte-identical to the body to be sent.
+		if err := c.setAuthHeaders(req, string(body)); err != nil {
 			return nil, err
 		}
 	}
@@ -127,11 +191,105 @@ func (c *Client) do(req *http.Request, output any) error {
 	defer resp.Body.Close() //nolint: errcheck // ignore error
 
 	if resp.StatusCode != http.StatusOK {
-		return withPrefixError(fmt.Errorf("unexpected status code=%d", resp.StatusCode))
+		return newAPIError(req, resp)
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(output); err != nil {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return withPrefixError(err)
+	}
+
+	if !c.rawEnvelope {
+		if env, ok := parseEnvelope(body); ok && env.Success != nil && !*env.Success {
+			return &APIError{
+				StatusCode: resp.StatusCode,
+				Message:    env.message(),
+				Method:     req.Method,
+				Endpoint:   req.URL.Path,
+				Header:     resp.Header,
+			}
+		}
+	}
+
+	if err := json.Unmarshal(body, output); err != nil {
 		return withPrefixError(err)
 	}
 	return nil
 }
+
+// envelope represents the common part of the Coincheck API response.
+// e.g. {"success":false,"error":"invalid authentication"}
+type envelope struct {
+	// Success is false if the request failed. It's nil if the response does not have the success field.
+	Success *bool `json:"success"`
+	// Error is the error message.
+	Error string `json:"error"`
+}
+
+// message returns the error message of the envelope.
+func (e envelope) message() string {
+	if e.Error == "" {
+		return "success is false"
+	}
+	return e.Error
+}
+
+// parseEnvelope parses the success/error envelope from the response body.
+// It returns false if the body is not a JSON object.
+func parseEnvelope(body []byte) (envelope, bool) {
+	var env envelope
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return env, false
+	}
+	if err := json.Unmarshal(trimmed, &env); err != nil {
+		return env, false
+	}
+	return env, true
+}
+
+// maxErrorBodySize is the maximum size of the error response body to be read.
+const maxErrorBodySize = 1 << 20
+
+// newAPIError returns an APIError from the response whose status code is not 200.
+func newAPIError(req *http.Request, resp *http.Response) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Method:     req.Method,
+		Endpoint:   req.URL.Path,
+		Header:     resp.Header,
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err != nil {
+		return apiErr
+	}
+
+	if env, ok := parseEnvelope(body); ok && env.Error != "" {
+		apiErr.Message = env.Error
+	} else {
+		apiErr.Message = strings.TrimSpace(string(body))
+	}
+	return apiErr
+}
+
+// forEachLimited calls fn for each index in [0, n) concurrently, at most limit calls at the same time.
+// It returns after all calls have returned. fn must report its own error, e.g. by writing it to a result slice.
+func forEachLimited(n, limit int, fn func(i int)) {
+	sem := make(chan struct{}, limit)
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		i := i
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn(i)
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,96 @@
+package coincheck
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_createRequest(t *testing.T) {
+	t.Run("createRequest signs nonce, URL and body that is sent", func(t *testing.T) {
+		const secret = "api_secret"
+
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			wantBody := `{"pair":"btc_jpy","amount":"0.1"}`
+			if diff := cmp.Diff(wantBody, string(body)); diff != "" {
+				printDiff(t, diff)
+			}
+
+			h := hmac.New(sha256.New, []byte(secret))
+			h.Write([]byte(r.Header.Get("ACCESS-NONCE") + "http://" + r.Host + r.URL.String() + string(body)))
+			wantSignature := hex.EncodeToString(h.Sum(nil))
+			if diff := cmp.Diff(wantSignature, r.Header.Get("ACCESS-SIGNATURE")); diff != "" {
+				printDiff(t, diff)
+			}
+
+			if diff := cmp.Diff("api_key", r.Header.Get("ACCESS-KEY")); diff != "" {
+				printDiff(t, diff)
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", secret),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req, err := client.createRequest(context.Background(), createRequestInput{
+			method: http.MethodPost,
+			path:   "/api/test",
+			body: struct {
+				Pair   Pair   `json:"pair"`
+				Amount string `json:"amount"`
+			}{
+				Pair:   PairBTCJPY,
+				Amount: "0.1",
+			},
+			queryParam: map[string]string{"foo": "bar"},
+			private:    true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resp, err := client.client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close() //nolint: errcheck // ignore error
+	})
+
+	t.Run("createRequest returns an error if the body can not be serialized", func(t *testing.T) {
+		client, err := NewClient(
+			WithBaseURL("https://example.com"),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = client.createRequest(context.Background(), createRequestInput{
+			method:  http.MethodPost,
+			path:    "/api/test",
+			body:    make(chan int),
+			private: true,
+		})
+		if err == nil {
+			t.Error("want error, but got nil")
+		}
+	})
+}
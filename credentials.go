@@ -6,7 +6,6 @@ import (
 	"encoding/hex"
 	"fmt"
 	"net/url"
-	"time"
 )
 
 // credentials represents the credentials used to authenticate with the coincheck API.
@@ -28,15 +27,15 @@ type requestHeaderParam struct {
 	// AccessKey is the API key.
 	AccessKey string
 	// AccessNonce is the positive integer that will increase every time you send a request.
-	// A common practice is to use UNIX time. Maximum value is 9223372036854775807.
+	// It's generated by the NonceSource of the client. Maximum value is 9223372036854775807.
 	AccessNonce string
 	// AccessSignature is the HMAC-SHA256 encoded message containing, ACCESS-NONCE, Request URL and Request body by using API key.
 	AccessSignature string
 }
 
 // generateRequestHeaders generates requestHeaderParam struct.
-func (c *credentials) generateRequestHeaders(requestURL *url.URL, body string) (*requestHeaderParam, error) {
-	nonce := time.Now().Unix()
+// The nonce must be greater than the nonce of the previous request with the same API key.
+func (c *credentials) generateRequestHeaders(nonce int64, requestURL *url.URL, body string) (*requestHeaderParam, error) {
 	message := fmt.Sprintf("%d%s%s", nonce, requestURL, body)
 
 	h := hmac.New(sha256.New, []byte(c.secret))
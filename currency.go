@@ -0,0 +1,88 @@
+package coincheck
+
+import "strings"
+
+// Currency represents the currency. e.g. jpy, btc.
+// The Coincheck API uses both lower case ("btc") and upper case ("JPY"),
+// so Currency is always normalized to lower case when it's decoded from JSON.
+type Currency string
+
+// String returns the string representation of the Currency.
+func (c Currency) String() string {
+	return string(c)
+}
+
+// UnmarshalText decodes the currency and normalizes it to lower case.
+func (c *Currency) UnmarshalText(text []byte) error {
+	*c = Currency(strings.ToLower(string(text)))
+	return nil
+}
+
+const (
+	// CurrencyJPY is Japanese Yen.
+	CurrencyJPY Currency = "jpy"
+	// CurrencyBTC is Bitcoin.
+	CurrencyBTC Currency = "btc"
+	// CurrencyETH is Ethereum.
+	CurrencyETH Currency = "eth"
+	// CurrencyETC is Ethereum Classic.
+	CurrencyETC Currency = "etc"
+	// CurrencyLsk is Lisk.
+	CurrencyLsk Currency = "lsk"
+	// CurrencyMona is MonaCoin.
+	CurrencyMona Currency = "mona"
+	// CurrencyPlt is Palette Token.
+	CurrencyPlt Currency = "plt"
+	// CurrencyFnct is FiNANCiE.
+	CurrencyFnct Currency = "fnct"
+	// CurrencyDai is DAI.
+	CurrencyDai Currency = "dai"
+	// CurrencyWbtc is Wrapped Bitcoin.
+	CurrencyWbtc Currency = "wbtc"
+	// CurrencyBril is Brilliantcrypto.
+	CurrencyBril Currency = "bril"
+)
+
+// Base returns the base currency of the pair. e.g. btc for btc_jpy.
+func (p Pair) Base() Currency {
+	base, _, _ := strings.Cut(string(p), "_")
+	return Currency(base)
+}
+
+// Quote returns the quote currency of the pair. e.g. jpy for btc_jpy.
+func (p Pair) Quote() Currency {
+	_, quote, _ := strings.Cut(string(p), "_")
+	return Currency(quote)
+}
+
+// ratePrecisions is the number of digits after the decimal point of the order rate.
+// It lists only the pairs whose tick size is confirmed with the order rules of the Coincheck exchange
+// (btc_jpy is ordered in 1 JPY). The tick size of the other pairs can be changed by Coincheck,
+// so they are not guessed.
+var ratePrecisions = map[Pair]int32{
+	PairBTCJPY: 0,
+}
+
+// amountPrecisions is the number of digits after the decimal point of the order amount.
+// btc_jpy is ordered in 0.00000001 BTC (1 satoshi), which is the smallest unit of Bitcoin.
+var amountPrecisions = map[Pair]int32{
+	PairBTCJPY: 8,
+}
+
+// RatePrecision returns the number of digits after the decimal point of the order rate of the pair.
+// e.g. btc_jpy is ordered in 1 JPY, so it returns 0.
+// It returns false if the tick size of the pair is unknown. In that case, check the order rules
+// on the Coincheck website instead of rounding the rate with a guess, or the order may be rejected.
+// Use it with Decimal.Round before creating an order.
+func (p Pair) RatePrecision() (int32, bool) {
+	precision, ok := ratePrecisions[p]
+	return precision, ok
+}
+
+// AmountPrecision returns the number of digits after the decimal point of the order amount of the pair.
+// It returns false if the amount unit of the pair is unknown.
+// Use it with Decimal.Truncate before creating an order, so the amount never exceeds your balance.
+func (p Pair) AmountPrecision() (int32, bool) {
+	precision, ok := amountPrecisions[p]
+	return precision, ok
+}
@@ -0,0 +1,53 @@
+package coincheck
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCurrency(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Currency is normalized to lower case when it's decoded from JSON", func(t *testing.T) {
+		t.Parallel()
+
+		var got struct {
+			FeeCurrency Currency            `json:"fee_currency"`
+			Funds       map[Currency]string `json:"funds"`
+		}
+		if err := json.Unmarshal([]byte(`{"fee_currency":"JPY","funds":{"BTC":"0.1"}}`), &got); err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(CurrencyJPY, got.FeeCurrency); diff != "" {
+			printDiff(t, diff)
+		}
+		if diff := cmp.Diff(map[Currency]string{CurrencyBTC: "0.1"}, got.Funds); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("Pair returns the base and quote currency", func(t *testing.T) {
+		t.Parallel()
+
+		if diff := cmp.Diff(CurrencyMona, PairMonaJPY.Base()); diff != "" {
+			printDiff(t, diff)
+		}
+		if diff := cmp.Diff(CurrencyJPY, PairMonaJPY.Quote()); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("Pair returns false for the precision of a pair whose tick size is unknown", func(t *testing.T) {
+		t.Parallel()
+
+		if _, ok := PairMonaJPY.RatePrecision(); ok {
+			t.Error("want false, but got true")
+		}
+		if _, ok := PairMonaJPY.AmountPrecision(); ok {
+			t.Error("want false, but got true")
+		}
+	})
+}
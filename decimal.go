@@ -0,0 +1,312 @@
+package coincheck
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact fixed-point decimal number for prices and amounts.
+// The value is coef * 10^(-scale). The zero value is 0.
+//
+// The Coincheck API returns prices and amounts as both JSON strings ("0.1") and JSON numbers (0.1).
+// Decimal can be decoded from both of them, and it's encoded as a JSON string so that
+// no precision is lost.
+//
+// Decimal is immutable. All arithmetic methods return a new Decimal.
+type Decimal struct {
+	// coef is the coefficient. nil means 0.
+	coef *big.Int
+	// scale is the number of digits after the decimal point.
+	scale int32
+}
+
+// NewDecimal returns coef * 10^(-scale). e.g. NewDecimal(12345, 2) is 123.45.
+func NewDecimal(coef int64, scale int32) Decimal {
+	return Decimal{coef: big.NewInt(coef), scale: scale}.normalizeScale()
+}
+
+// NewDecimalFromFloat returns the Decimal of the shortest decimal representation of f.
+// e.g. NewDecimalFromFloat(0.1) is exactly 0.1.
+func NewDecimalFromFloat(f float64) Decimal {
+	d, err := ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+	if err != nil {
+		return Decimal{}
+	}
+	return d
+}
+
+// maxDecimalScale is the maximum absolute value of the scale accepted by ParseDecimal.
+// It's far beyond any price or amount of the Coincheck API, and it prevents an exponent
+// such as "1e999999999" in the server response from allocating a huge number.
+const maxDecimalScale = 1000
+
+// ParseDecimal parses a decimal string. e.g. "123", "-0.001", "1.5e-3".
+func ParseDecimal(s string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Decimal{}, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+	}
+
+	in := s
+	var exp int64
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil {
+			return Decimal{}, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+		}
+		exp = e
+		s = s[:i]
+	}
+
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	sign := ""
+	if strings.HasPrefix(intPart, "-") || strings.HasPrefix(intPart, "+") {
+		sign, intPart = intPart[:1], intPart[1:]
+	}
+	// The sign is allowed only as the first character, e.g. ".-5" is invalid.
+	if intPart+fracPart == "" || !isDigits(intPart) || !isDigits(fracPart) {
+		return Decimal{}, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+	}
+	coef, ok := new(big.Int).SetString(sign+intPart+fracPart, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+	}
+
+	scale := int64(len(fracPart)) - exp
+	if scale > maxDecimalScale || scale < -maxDecimalScale {
+		return Decimal{}, fmt.Errorf("%w: %q: exponent is out of range", ErrInvalidDecimal, in)
+	}
+	if scale < 0 {
+		coef.Mul(coef, pow10(int32(-scale)))
+		scale = 0
+	}
+	return Decimal{coef: coef, scale: int32(scale)}, nil
+}
+
+// isDigits returns true if s consists of only ASCII digits. It returns true for an empty string.
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// MustParseDecimal is like ParseDecimal but panics if s can not be parsed.
+// It's useful for constants. e.g. coincheck.MustParseDecimal("0.005")
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// pow10 returns 10^n.
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// int returns the coefficient. It never returns nil.
+func (d Decimal) int() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// normalizeScale makes the scale non-negative.
+func (d Decimal) normalizeScale() Decimal {
+	if d.scale >= 0 {
+		return d
+	}
+	return Decimal{coef: new(big.Int).Mul(d.int(), pow10(-d.scale)), scale: 0}
+}
+
+// rescale returns the coefficient of d at the scale. scale must be greater than or equal to d.scale.
+func (d Decimal) rescale(scale int32) *big.Int {
+	if scale == d.scale {
+		return new(big.Int).Set(d.int())
+	}
+	return new(big.Int).Mul(d.int(), pow10(scale-d.scale))
+}
+
+// align returns the coefficients of d and e at the same scale.
+func align(d, e Decimal) (*big.Int, *big.Int, int32) {
+	scale := d.scale
+	if e.scale > scale {
+		scale = e.scale
+	}
+	return d.rescale(scale), e.rescale(scale), scale
+}
+
+// Add returns d + e.
+func (d Decimal) Add(e Decimal) Decimal {
+	a, b, scale := align(d, e)
+	return Decimal{coef: a.Add(a, b), scale: scale}
+}
+
+// Sub returns d - e.
+func (d Decimal) Sub(e Decimal) Decimal {
+	a, b, scale := align(d, e)
+	return Decimal{coef: a.Sub(a, b), scale: scale}
+}
+
+// Mul returns d * e.
+func (d Decimal) Mul(e Decimal) Decimal {
+	return Decimal{coef: new(big.Int).Mul(d.int(), e.int()), scale: d.scale + e.scale}
+}
+
+// Div returns d / e rounded to places digits after the decimal point (half away from zero).
+// It panics if e is zero, like integer division.
+func (d Decimal) Div(e Decimal, places int32) Decimal {
+	if e.IsZero() {
+		panic("coincheck: division by zero")
+	}
+	// d / e = (d.coef * 10^(places + 1 + e.scale - d.scale)) / e.coef * 10^(-(places + 1))
+	shift := places + 1 + e.scale - d.scale
+	num := d.int()
+	den := e.int()
+	if shift >= 0 {
+		num = new(big.Int).Mul(num, pow10(shift))
+	} else {
+		den = new(big.Int).Mul(den, pow10(-shift))
+	}
+	q := new(big.Int).Quo(num, den)
+	return Decimal{coef: q, scale: places + 1}.Round(places)
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{coef: new(big.Int).Neg(d.int()), scale: d.scale}
+}
+
+// Abs returns |d|.
+func (d Decimal) Abs() Decimal {
+	return Decimal{coef: new(big.Int).Abs(d.int()), scale: d.scale}
+}
+
+// Sign returns -1 if d < 0, 0 if d == 0 and +1 if d > 0.
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+// IsZero returns true if d is 0.
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Cmp compares d and e. It returns -1 if d < e, 0 if d == e and +1 if d > e.
+func (d Decimal) Cmp(e Decimal) int {
+	a, b, _ := align(d, e)
+	return a.Cmp(b)
+}
+
+// Equal returns true if d and e are the same number. e.g. 0.10 equals 0.1.
+func (d Decimal) Equal(e Decimal) bool {
+	return d.Cmp(e) == 0
+}
+
+// LessThan returns true if d < e.
+func (d Decimal) LessThan(e Decimal) bool {
+	return d.Cmp(e) < 0
+}
+
+// GreaterThan returns true if d > e.
+func (d Decimal) GreaterThan(e Decimal) bool {
+	return d.Cmp(e) > 0
+}
+
+// Round rounds d to places digits after the decimal point (half away from zero).
+// e.g. 1.2345 rounded to 2 places is 1.23, -1.235 is -1.24.
+func (d Decimal) Round(places int32) Decimal {
+	if places >= d.scale {
+		return d
+	}
+	den := pow10(d.scale - places)
+	q, r := new(big.Int).QuoRem(d.int(), den, new(big.Int))
+	// Round half away from zero: |r| * 2 >= den.
+	r.Abs(r).Mul(r, big.NewInt(2))
+	if r.Cmp(den) >= 0 {
+		if d.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return Decimal{coef: q, scale: places}.normalizeScale()
+}
+
+// Truncate truncates d to places digits after the decimal point (toward zero).
+// e.g. 1.239 truncated to 2 places is 1.23.
+func (d Decimal) Truncate(places int32) Decimal {
+	if places >= d.scale {
+		return d
+	}
+	q := new(big.Int).Quo(d.int(), pow10(d.scale-places))
+	return Decimal{coef: q, scale: places}.normalizeScale()
+}
+
+// String returns the decimal representation of d without exponent. e.g. "123.45".
+// The digits after the decimal point are kept as they are parsed (e.g. "0.10" stays "0.10").
+func (d Decimal) String() string {
+	s := d.int().String()
+	if d.scale <= 0 {
+		return s
+	}
+
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	if len(s) <= int(d.scale) {
+		s = strings.Repeat("0", int(d.scale)-len(s)+1) + s
+	}
+	s = s[:len(s)-int(d.scale)] + "." + s[len(s)-int(d.scale):]
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
+
+// Float64 returns the nearest float64 of d.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// MarshalJSON encodes d as a JSON string. e.g. "0.1"
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+// UnmarshalJSON decodes d from a JSON string ("0.1"), a JSON number (0.1) or null.
+// null and an empty string are decoded as 0.
+func (d *Decimal) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if string(b) == "null" {
+		*d = Decimal{}
+		return nil
+	}
+
+	s := string(b)
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return withPrefixError(err)
+		}
+		if strings.TrimSpace(s) == "" {
+			*d = Decimal{}
+			return nil
+		}
+	}
+
+	parsed, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
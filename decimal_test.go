@@ -0,0 +1,183 @@
+package coincheck
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDecimal(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ParseDecimal parses a decimal string and String keeps the digits", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			in   string
+			want string
+		}{
+			{in: "0", want: "0"},
+			{in: "123", want: "123"},
+			{in: "0.10", want: "0.10"},
+			{in: "-0.001", want: "-0.001"},
+			{in: ".5", want: "0.5"},
+			{in: "-.5", want: "-0.5"},
+			{in: "+1.5", want: "1.5"},
+			{in: "1.5e-3", want: "0.0015"},
+			{in: "2E3", want: "2000"},
+			{in: "10941978.53166054", want: "10941978.53166054"},
+			{in: "1e1000", want: "1" + strings.Repeat("0", 1000)},
+			{in: "1e-1000", want: "0." + strings.Repeat("0", 999) + "1"},
+		}
+		for _, tt := range tests {
+			got, err := ParseDecimal(tt.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got.String()); diff != "" {
+				printDiff(t, diff)
+			}
+		}
+	})
+
+	t.Run("ParseDecimal returns ErrInvalidDecimal for an invalid string", func(t *testing.T) {
+		t.Parallel()
+
+		for _, in := range []string{
+			"", "-", "abc", "1.2.3", "1e", "0x10", ".-5", ".+5", "1.-5", "-+1", "1-", "1_000",
+			"1e-2147483648", "1e2147483647", "1e999999999", "1e-999999999", "1e1001", "1e-1001",
+		} {
+			if _, err := ParseDecimal(in); !errors.Is(err, ErrInvalidDecimal) {
+				t.Errorf("%q: error is not ErrInvalidDecimal: %v", in, err)
+			}
+		}
+	})
+
+	t.Run("Decimal is decoded from a JSON string, a JSON number and null", func(t *testing.T) {
+		t.Parallel()
+
+		var got struct {
+			Quoted Decimal `json:"quoted"`
+			Bare   Decimal `json:"bare"`
+			Null   Decimal `json:"null"`
+			Empty  Decimal `json:"empty"`
+		}
+		in := `{"quoted":"0.12345678901234567890","bare":26890.5,"null":null,"empty":""}`
+		if err := json.Unmarshal([]byte(in), &got); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff("0.12345678901234567890", got.Quoted.String()); diff != "" {
+			printDiff(t, diff)
+		}
+		if diff := cmp.Diff("26890.5", got.Bare.String()); diff != "" {
+			printDiff(t, diff)
+		}
+		if !got.Null.IsZero() || !got.Empty.IsZero() {
+			t.Errorf("null and empty string must be 0: %v, %v", got.Null, got.Empty)
+		}
+
+		b, err := json.Marshal(got.Bare)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(`"26890.5"`, string(b)); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("Decimal arithmetic is exact", func(t *testing.T) {
+		t.Parallel()
+
+		a := MustParseDecimal("0.1")
+		b := MustParseDecimal("0.2")
+		if diff := cmp.Diff("0.3", a.Add(b).String()); diff != "" {
+			printDiff(t, diff)
+		}
+		if diff := cmp.Diff("-0.1", a.Sub(b).String()); diff != "" {
+			printDiff(t, diff)
+		}
+		if diff := cmp.Diff("0.02", a.Mul(b).String()); diff != "" {
+			printDiff(t, diff)
+		}
+
+		price := MustParseDecimal("4096135")
+		amount := MustParseDecimal("0.00123456")
+		if diff := cmp.Diff("5056.92442560", price.Mul(amount).String()); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("Div rounds half away from zero", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			a, b   string
+			places int32
+			want   string
+		}{
+			{a: "1", b: "3", places: 4, want: "0.3333"},
+			{a: "2", b: "3", places: 4, want: "0.6667"},
+			{a: "-2", b: "3", places: 2, want: "-0.67"},
+			{a: "10", b: "0.25", places: 0, want: "40"},
+			{a: "1", b: "8", places: 2, want: "0.13"},
+		}
+		for _, tt := range tests {
+			got := MustParseDecimal(tt.a).Div(MustParseDecimal(tt.b), tt.places)
+			if diff := cmp.Diff(tt.want, got.String()); diff != "" {
+				printDiff(t, diff)
+			}
+		}
+	})
+
+	t.Run("Round and Truncate", func(t *testing.T) {
+		t.Parallel()
+
+		d := MustParseDecimal("-1.235")
+		if diff := cmp.Diff("-1.24", d.Round(2).String()); diff != "" {
+			printDiff(t, diff)
+		}
+		if diff := cmp.Diff("-1.23", d.Truncate(2).String()); diff != "" {
+			printDiff(t, diff)
+		}
+		ratePrecision, ok := PairBTCJPY.RatePrecision()
+		if !ok {
+			t.Fatal("rate precision of btc_jpy is unknown")
+		}
+		if diff := cmp.Diff("28001", MustParseDecimal("28000.5").Round(ratePrecision).String()); diff != "" {
+			printDiff(t, diff)
+		}
+		amountPrecision, ok := PairBTCJPY.AmountPrecision()
+		if !ok {
+			t.Fatal("amount precision of btc_jpy is unknown")
+		}
+		if diff := cmp.Diff("0.12345678", MustParseDecimal("0.123456789").Truncate(amountPrecision).String()); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("Cmp compares the numbers regardless of the digits", func(t *testing.T) {
+		t.Parallel()
+
+		if !MustParseDecimal("0.10").Equal(MustParseDecimal("0.1")) {
+			t.Error("0.10 must equal 0.1")
+		}
+		if !MustParseDecimal("0.09").LessThan(MustParseDecimal("0.1")) {
+			t.Error("0.09 must be less than 0.1")
+		}
+		if !MustParseDecimal("-0.09").GreaterThan(MustParseDecimal("-0.1")) {
+			t.Error("-0.09 must be greater than -0.1")
+		}
+		if !(Decimal{}).Equal(NewDecimal(0, 3)) {
+			t.Error("zero value must equal 0")
+		}
+		if diff := cmp.Diff("123.45", NewDecimal(12345, 2).String()); diff != "" {
+			printDiff(t, diff)
+		}
+		if diff := cmp.Diff("0.1", NewDecimalFromFloat(0.1).String()); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+}
@@ -0,0 +1,131 @@
+package coincheck
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// DepositStatus represents the status of a crypto deposit.
+type DepositStatus string
+
+// String returns the string representation of the DepositStatus.
+func (s DepositStatus) String() string {
+	return string(s)
+}
+
+const (
+	// DepositStatusReceived means the deposit was received but not confirmed yet.
+	// You can request a fast deposit with RequestFastDeposit.
+	DepositStatusReceived DepositStatus = "received"
+	// DepositStatusConfirmed means the deposit was confirmed and added to your balance.
+	DepositStatusConfirmed DepositStatus = "confirmed"
+)
+
+// Deposit represents a crypto deposit.
+type Deposit struct {
+	// ID is the deposit ID.
+	ID int `json:"id"`
+	// Amount is the deposited amount.
+	Amount Decimal `json:"amount"`
+	// Currency is the deposited currency.
+	Currency Currency `json:"currency"`
+	// Address is the deposit address.
+	Address string `json:"address"`
+	// Status is the status of the deposit.
+	Status DepositStatus `json:"status"`
+	// ConfirmedAt is the time when the deposit was confirmed. It's zero if the deposit is not confirmed.
+	ConfirmedAt Time `json:"confirmed_at"`
+	// CreatedAt is the time when the deposit was received.
+	CreatedAt Time `json:"created_at"`
+}
+
+// GetDepositHistoryInput represents the input parameter for the GetDepositHistory method.
+type GetDepositHistoryInput struct {
+	// Currency is the currency of the deposits. e.g. btc. If you don't set it, the Coincheck API uses btc.
+	Currency Currency
+	// Pagination is the pagination of the data. If you don't set it, the Coincheck API uses its default values.
+	Pagination Pagination
+}
+
+// GetDepositHistoryResponse represents the output from the GetDepositHistory method.
+type GetDepositHistoryResponse struct {
+	// Success is a boolean value that indicates the success of the API call.
+	Success bool `json:"success"`
+	// Pagination is the pagination of the data.
+	Pagination Pagination `json:"pagination"`
+	// Deposits is a list of your crypto deposits.
+	Deposits []Deposit `json:"deposits"`
+}
+
+// GetDepositHistory returns the history of your crypto deposits.
+// API: GET /api/deposit_money
+// Visibility: Private
+// If you want to get all deposits, use NewDepositPaginator.
+func (c *Client) GetDepositHistory(ctx context.Context, input GetDepositHistoryInput) (*GetDepositHistoryResponse, error) {
+	queryParam := input.Pagination.queryParam()
+	if input.Currency != "" {
+		queryParam["currency"] = strings.ToUpper(input.Currency.String())
+	}
+	req, err := c.createRequest(ctx, createRequestInput{
+		method:     http.MethodGet,
+		path:       "/api/deposit_money",
+		queryParam: queryParam,
+		private:    true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var output GetDepositHistoryResponse
+	if err := c.do(req, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// NewDepositPaginator returns a Paginator over GetDepositHistory of the currency.
+func (c *Client) NewDepositPaginator(currency Currency, input PaginatorInput) *Paginator[Deposit] {
+	fetch := func(ctx context.Context, pagination Pagination) ([]Deposit, error) {
+		resp, err := c.GetDepositHistory(ctx, GetDepositHistoryInput{Currency: currency, Pagination: pagination})
+		if err != nil {
+			return nil, err
+		}
+		return resp.Deposits, nil
+	}
+	return NewPaginator(fetch, func(d Deposit) int { return d.ID }, input)
+}
+
+// RequestFastDepositInput represents the input parameter for the RequestFastDeposit method.
+type RequestFastDepositInput struct {
+	// ID is the ID of the unconfirmed deposit (DepositStatusReceived).
+	ID int
+}
+
+// RequestFastDepositResponse represents the output from the RequestFastDeposit method.
+type RequestFastDepositResponse struct {
+	// Success is a boolean value that indicates the success of the API call.
+	Success bool `json:"success"`
+}
+
+// RequestFastDeposit requests a fast deposit of the unconfirmed deposit,
+// so it's added to your balance before it's confirmed.
+// API: POST /api/deposit_money/[id]/fast
+// Visibility: Private
+func (c *Client) RequestFastDeposit(ctx context.Context, input RequestFastDepositInput) (*RequestFastDepositResponse, error) {
+	req, err := c.createRequest(ctx, createRequestInput{
+		method:  http.MethodPost,
+		path:    "/api/deposit_money/" + strconv.Itoa(input.ID) + "/fast",
+		private: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var output RequestFastDepositResponse
+	if err := c.do(req, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
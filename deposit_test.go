@@ -0,0 +1,143 @@
+package coincheck
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_GetDepositHistory(t *testing.T) {
+	t.Run("GetDepositHistory returns the deposits of the currency", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			wantMethod := http.MethodGet
+			if diff := cmp.Diff(wantMethod, r.Method); diff != "" {
+				printDiff(t, diff)
+			}
+
+			wantEndpoint := "/api/deposit_money"
+			if diff := cmp.Diff(wantEndpoint, r.URL.Path); diff != "" {
+				printDiff(t, diff)
+			}
+
+			wantQuery := "currency=BTC&order=asc"
+			if diff := cmp.Diff(wantQuery, r.URL.RawQuery); diff != "" {
+				printDiff(t, diff)
+			}
+
+			w.Write([]byte(`{
+				"success": true,
+				"deposits": [
+					{
+						"id": 2,
+						"amount": "0.05",
+						"currency": "BTC",
+						"address": "13PhzoK8me3u5nHzzFD85qT9RqEWR9M4Ty",
+						"status": "confirmed",
+						"confirmed_at": "2015-06-13T08:29:18.000Z",
+						"created_at": "2015-06-13T08:22:18.000Z"
+					},
+					{
+						"id": 3,
+						"amount": "0.001",
+						"currency": "BTC",
+						"address": "13PhzoK8me3u5nHzzFD85qT9RqEWR9M4Ty",
+						"status": "received",
+						"confirmed_at": null,
+						"created_at": "2015-06-13T08:21:18.000Z"
+					}
+				]
+			}`)) //nolint: errcheck // ignore error
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := client.GetDepositHistory(context.Background(), GetDepositHistoryInput{
+			Currency:   CurrencyBTC,
+			Pagination: Pagination{PaginationOrder: PaginationOrderAsc},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []Deposit{
+			{
+				ID:          2,
+				Amount:      MustParseDecimal("0.05"),
+				Currency:    CurrencyBTC,
+				Address:     "13PhzoK8me3u5nHzzFD85qT9RqEWR9M4Ty",
+				Status:      DepositStatusConfirmed,
+				ConfirmedAt: NewTime(time.Date(2015, 6, 13, 8, 29, 18, 0, time.UTC)),
+				CreatedAt:   NewTime(time.Date(2015, 6, 13, 8, 22, 18, 0, time.UTC)),
+			},
+			{
+				ID:        3,
+				Amount:    MustParseDecimal("0.001"),
+				Currency:  CurrencyBTC,
+				Address:   "13PhzoK8me3u5nHzzFD85qT9RqEWR9M4Ty",
+				Status:    DepositStatusReceived,
+				CreatedAt: NewTime(time.Date(2015, 6, 13, 8, 21, 18, 0, time.UTC)),
+			},
+		}
+		if diff := cmp.Diff(want, got.Deposits, equateTime); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("GetDepositHistory returns an error if client does not set credentials", func(t *testing.T) {
+		client, err := NewClient(WithBaseURL("https://example.com"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := client.GetDepositHistory(context.Background(), GetDepositHistoryInput{}); !errors.Is(err, ErrNoCredentials) {
+			t.Errorf("error is not ErrNoCredentials: %v", err)
+		}
+	})
+}
+
+func TestClient_RequestFastDeposit(t *testing.T) {
+	t.Run("RequestFastDeposit requests a fast deposit", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			wantMethod := http.MethodPost
+			if diff := cmp.Diff(wantMethod, r.Method); diff != "" {
+				printDiff(t, diff)
+			}
+
+			wantEndpoint := "/api/deposit_money/3/fast"
+			if diff := cmp.Diff(wantEndpoint, r.URL.Path); diff != "" {
+				printDiff(t, diff)
+			}
+
+			w.Write([]byte(`{"success":true}`)) //nolint: errcheck // ignore error
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := client.RequestFastDeposit(context.Background(), RequestFastDepositInput{ID: 3})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(&RequestFastDepositResponse{Success: true}, got); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+}
@@ -1,21 +1,132 @@
 package coincheck
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
 
 var (
 	// ErrNilHTTPClient means specified http client is nil.
 	ErrNilHTTPClient = errors.New("coincheck: specified HTTP client is nil")
 	// ErrInvalidBaseURL means specified base URL is invalid.
 	ErrInvalidBaseURL = errors.New("coincheck: specified base URL is invalid")
+	// ErrInvalidWebSocketURL means specified WebSocket URL is invalid.
+	ErrInvalidWebSocketURL = errors.New("coincheck: specified WebSocket URL is invalid")
 	// ErrGenerateRequestHeaders means failed to generate request headers.
 	// If this error occurs, you should check the API key and API secret.
 	ErrGenerateRequestHeaders = errors.New("coincheck: failed to generate request headers")
 	// ErrNoCredentials means specified credentials is nil.
 	ErrNoCredentials = errors.New("coincheck: specified credentials is nil")
+	// ErrNilNonceSource means specified nonce source is nil.
+	ErrNilNonceSource = errors.New("coincheck: specified nonce source is nil")
+	// ErrNonceSource means failed to generate a nonce.
+	ErrNonceSource = errors.New("coincheck: failed to generate a nonce")
+	// ErrInvalidDecimal means specified string is not a decimal number.
+	ErrInvalidDecimal = errors.New("coincheck: invalid decimal")
+	// ErrInvalidOrder means specified order parameters are invalid.
+	// The order is not sent to the Coincheck API.
+	ErrInvalidOrder = errors.New("coincheck: invalid order")
+	// ErrInsufficientLiquidity means the order book does not have enough amount to fill the order.
+	ErrInsufficientLiquidity = errors.New("coincheck: insufficient liquidity")
+	// ErrInvalidOrderBook means the order book has an invalid level (e.g. a zero price).
+	ErrInvalidOrderBook = errors.New("coincheck: invalid order book")
+	// ErrOrderBookOutOfSync means a difference of the order book is older than the snapshot.
+	// The local order book is synchronized with GetOrderBooks again.
+	ErrOrderBookOutOfSync = errors.New("coincheck: order book is out of sync")
+	// ErrInvalidStreamMessage means a message from the WebSocket API can not be decoded.
+	ErrInvalidStreamMessage = errors.New("coincheck: invalid stream message")
+	// ErrInvalidCandleInterval means specified candle interval is not between 1 second and 24 hours.
+	ErrInvalidCandleInterval = errors.New("coincheck: invalid candle interval")
+	// ErrInvalidSendMoney means specified parameters of the crypto transfer are invalid (e.g. a malformed address).
+	// The transfer is not sent to the Coincheck API.
+	ErrInvalidSendMoney = errors.New("coincheck: invalid send money")
+	// ErrInvalidBankAccount means specified bank account parameters are invalid.
+	// The bank account is not sent to the Coincheck API.
+	ErrInvalidBankAccount = errors.New("coincheck: invalid bank account")
+	// ErrInvalidWithdrawal means specified withdrawal parameters are invalid.
+	// The withdrawal is not sent to the Coincheck API.
+	ErrInvalidWithdrawal = errors.New("coincheck: invalid withdrawal")
+	// ErrNilSendMoneyConfirmer means specified send money confirmer is nil.
+	ErrNilSendMoneyConfirmer = errors.New("coincheck: specified send money confirmer is nil")
+	// ErrSendMoneyNotConfirmed means the crypto transfer was not approved by the SendMoneyConfirmer,
+	// or the client does not have a SendMoneyConfirmer. The transfer is not sent to the Coincheck API.
+	ErrSendMoneyNotConfirmed = errors.New("coincheck: send money is not confirmed")
+)
+
+var (
+	// ErrUnauthorized means the Coincheck API rejected the credentials or the signature.
+	// It's used to classify an APIError with errors.Is.
+	ErrUnauthorized = errors.New("coincheck: unauthorized")
+	// ErrRateLimited means the request was rejected by the rate limit of the Coincheck API.
+	// It's used to classify an APIError with errors.Is.
+	ErrRateLimited = errors.New("coincheck: rate limited")
+	// ErrInvalidNonce means the ACCESS-NONCE was not greater than the previous one.
+	// It's used to classify an APIError with errors.Is.
+	ErrInvalidNonce = errors.New("coincheck: invalid nonce")
+	// ErrInsufficientFunds means the balance is not enough for the request.
+	// It's used to classify an APIError with errors.Is.
+	ErrInsufficientFunds = errors.New("coincheck: insufficient funds")
+	// ErrMaintenance means the Coincheck API is under maintenance.
+	// It's used to classify an APIError with errors.Is.
+	ErrMaintenance = errors.New("coincheck: under maintenance")
 )
 
+// APIError represents an error response from the Coincheck API.
+// You can classify it with errors.Is (e.g. errors.Is(err, ErrRateLimited)),
+// and get the details with errors.As.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Message is the error message returned by the Coincheck API ({"success":false,"error":"..."}).
+	// If the response body is not JSON, it's the response body itself.
+	Message string
+	// Method is the HTTP method of the request.
+	Method string
+	// Endpoint is the API path of the request (e.g. /api/accounts/balance).
+	Endpoint string
+	// Header is the HTTP header of the response.
+	Header http.Header
+}
+
+// Error returns the string representation of the APIError.
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("coincheck: %s %s: status code=%d", e.Method, e.Endpoint, e.StatusCode)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// Is reports whether the APIError is classified as target.
+// target is one of ErrUnauthorized, ErrRateLimited, ErrInvalidNonce, ErrInsufficientFunds and ErrMaintenance.
+func (e *APIError) Is(target error) bool {
+	msg := strings.ToLower(e.Message)
+
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || strings.Contains(msg, "authentication")
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrInvalidNonce:
+		return strings.Contains(msg, "nonce")
+	case ErrInsufficientFunds:
+		return strings.Contains(msg, "insufficient") ||
+			strings.Contains(msg, "not enough") ||
+			strings.Contains(msg, "exceeds your available") ||
+			strings.Contains(msg, "残高が不足")
+	case ErrMaintenance:
+		return e.StatusCode == http.StatusServiceUnavailable ||
+			strings.Contains(msg, "maintenance") ||
+			strings.Contains(msg, "メンテナンス")
+	}
+	return false
+}
+
 // withPrefixError returns an error with the package prefix.
+// The returned error wraps err, so errors.Is and errors.As work with it.
 func withPrefixError(err error) error {
 	const prefix = "coincheck"
-	return errors.New(prefix + ": " + err.Error())
+	return fmt.Errorf("%s: %w", prefix, err)
 }
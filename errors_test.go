@@ -0,0 +1,218 @@
+package coincheck
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAPIError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Client returns APIError with the Coincheck error message", func(t *testing.T) {
+		t.Parallel()
+
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("X-Request-Id", "abc")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"success":false,"error":"Nonce must be incremented"}`)) //nolint: errcheck // ignore error
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = client.GetAccountsBalance(context.Background())
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("error is not APIError: %v", err)
+		}
+		if diff := cmp.Diff(http.StatusBadRequest, apiErr.StatusCode); diff != "" {
+			printDiff(t, diff)
+		}
+		if diff := cmp.Diff("Nonce must be incremented", apiErr.Message); diff != "" {
+			printDiff(t, diff)
+		}
+		if diff := cmp.Diff(http.MethodGet, apiErr.Method); diff != "" {
+			printDiff(t, diff)
+		}
+		if diff := cmp.Diff("/api/accounts/balance", apiErr.Endpoint); diff != "" {
+			printDiff(t, diff)
+		}
+		if diff := cmp.Diff("abc", apiErr.Header.Get("X-Request-Id")); diff != "" {
+			printDiff(t, diff)
+		}
+		if !errors.Is(err, ErrInvalidNonce) {
+			t.Errorf("error is not ErrInvalidNonce: %v", err)
+		}
+		if errors.Is(err, ErrUnauthorized) {
+			t.Errorf("error must not be ErrUnauthorized: %v", err)
+		}
+	})
+
+	t.Run("APIError is classified by the status code and the message", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name   string
+			err    *APIError
+			target error
+		}{
+			{
+				name:   "unauthorized",
+				err:    &APIError{StatusCode: http.StatusUnauthorized},
+				target: ErrUnauthorized,
+			},
+			{
+				name:   "invalid authentication",
+				err:    &APIError{StatusCode: http.StatusBadRequest, Message: "invalid authentication"},
+				target: ErrUnauthorized,
+			},
+			{
+				name:   "rate limited",
+				err:    &APIError{StatusCode: http.StatusTooManyRequests},
+				target: ErrRateLimited,
+			},
+			{
+				name:   "insufficient funds",
+				err:    &APIError{StatusCode: http.StatusBadRequest, Message: "Amount exceeds your available balance"},
+				target: ErrInsufficientFunds,
+			},
+			{
+				name:   "maintenance by status code",
+				err:    &APIError{StatusCode: http.StatusServiceUnavailable},
+				target: ErrMaintenance,
+			},
+			{
+				name:   "maintenance by message",
+				err:    &APIError{StatusCode: http.StatusBadRequest, Message: "The exchange is under maintenance"},
+				target: ErrMaintenance,
+			},
+		}
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				if !errors.Is(withPrefixError(tt.err), tt.target) {
+					t.Errorf("error is not %v: %v", tt.target, tt.err)
+				}
+			})
+		}
+	})
+
+	t.Run("APIError keeps the body if it's not JSON", func(t *testing.T) {
+		t.Parallel()
+
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(WithBaseURL(testServer.URL))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = client.GetTicker(context.Background(), GetTickerInput{Pair: PairBTCJPY})
+		want := "coincheck: GET /api/ticker: status code=500: Internal Server Error"
+		if diff := cmp.Diff(want, err.Error()); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("Client returns APIError if the response has success false with HTTP 200", func(t *testing.T) {
+		t.Parallel()
+
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Write([]byte(`{"success":false,"error":"invalid authentication"}`)) //nolint: errcheck // ignore error
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = client.GetBankAccounts(context.Background())
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("error is not APIError: %v", err)
+		}
+		if diff := cmp.Diff(http.StatusOK, apiErr.StatusCode); diff != "" {
+			printDiff(t, diff)
+		}
+		if diff := cmp.Diff("invalid authentication", apiErr.Message); diff != "" {
+			printDiff(t, diff)
+		}
+		if !errors.Is(err, ErrUnauthorized) {
+			t.Errorf("error is not ErrUnauthorized: %v", err)
+		}
+	})
+
+	t.Run("Client returns the raw envelope if WithRawEnvelope is set", func(t *testing.T) {
+		t.Parallel()
+
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Write([]byte(`{"success":false,"error":"invalid authentication"}`)) //nolint: errcheck // ignore error
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+			WithRawEnvelope(),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := client.GetBankAccounts(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Success {
+			t.Error("Success must be false")
+		}
+	})
+
+	t.Run("Client does not treat responses without the success field as errors", func(t *testing.T) {
+		t.Parallel()
+
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Write([]byte(`{"rate":"100"}`)) //nolint: errcheck // ignore error
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(WithBaseURL(testServer.URL))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := client.GetRate(context.Background(), GetRateInput{}); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("withPrefixError preserves the wrapped error", func(t *testing.T) {
+		t.Parallel()
+
+		if err := withPrefixError(ErrNoCredentials); !errors.Is(err, ErrNoCredentials) {
+			t.Errorf("error is not ErrNoCredentials: %v", err)
+		}
+	})
+}
@@ -24,8 +24,8 @@ type ExchangeStatus struct {
 	Pair Pair `json:"pair"`
 	// Status is the exchange status (available, itayose, stop).
 	Status ExchangeStatusAvailability `json:"status"`
-	// Timestamp is the time of the status.
-	Timestamp float64 `json:"timestamp"`
+	// Timestamp is the time of the status. It's Unix Timestamp in the response.
+	Timestamp Time `json:"timestamp"`
 	// Availability response whether limit orders ( order ), market orders ( market_order ), or cancel orders ( cancel ) can be placed.
 	Availability Availability `json:"availability"`
 }
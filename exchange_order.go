@@ -0,0 +1,574 @@
+package coincheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// TimeInForce represents the time in force of the order.
+type TimeInForce string
+
+// String returns the string representation of the TimeInForce.
+func (t TimeInForce) String() string {
+	return string(t)
+}
+
+const (
+	// TimeInForceGoodTilCancelled means the order remains until it is executed or cancelled.
+	// It's the default value of the Coincheck API.
+	TimeInForceGoodTilCancelled TimeInForce = "good_til_cancelled"
+	// TimeInForcePostOnly means the order is only accepted as a maker order.
+	// If the order would be executed immediately, it is cancelled.
+	TimeInForcePostOnly TimeInForce = "post_only"
+)
+
+// CreateOrderInput represents the input parameter for the CreateOrder method.
+//
+// The required fields depend on the OrderType.
+//   - OrderTypeBuy, OrderTypeSell (limit order): Rate and Amount are required.
+//   - OrderTypeMarketBuy: MarketBuyAmount (in JPY) is required. Rate and Amount must not be set.
+//   - OrderTypeMarketSell: Amount is required. Rate and MarketBuyAmount must not be set.
+type CreateOrderInput struct {
+	// Pair is the pair of the currency. e.g. btc_jpy.
+	Pair Pair
+	// OrderType is the order type (buy, sell, market_buy, market_sell).
+	OrderType OrderType
+	// Rate is the order rate. e.g. 28000. It's used for limit orders.
+	// Round it with Pair.RatePrecision before creating an order.
+	Rate *Decimal
+	// Amount is the order amount. e.g. 0.1. It's used for limit orders and market sell orders.
+	Amount *Decimal
+	// MarketBuyAmount is the market buy amount in JPY. e.g. 10000. It's used for market buy orders.
+	MarketBuyAmount *Decimal
+	// StopLossRate is the stop loss rate. If you don't need it, set nil.
+	StopLossRate *Decimal
+	// TimeInForce is the time in force of the order.
+	// If you don't set it, the Coincheck API uses "good_til_cancelled".
+	// "post_only" can not be used with market orders.
+	TimeInForce TimeInForce
+}
+
+// validate validates the CreateOrderInput.
+func (i CreateOrderInput) validate() error {
+	if i.Pair == "" {
+		return fmt.Errorf("%w: pair is required", ErrInvalidOrder)
+	}
+
+	for _, v := range []struct {
+		name  string
+		value *Decimal
+	}{
+		{name: "rate", value: i.Rate},
+		{name: "amount", value: i.Amount},
+		{name: "market_buy_amount", value: i.MarketBuyAmount},
+		{name: "stop_loss_rate", value: i.StopLossRate},
+	} {
+		if v.value != nil && v.value.Sign() <= 0 {
+			return fmt.Errorf("%w: %s must be greater than 0", ErrInvalidOrder, v.name)
+		}
+	}
+
+	switch i.OrderType {
+	case OrderTypeBuy, OrderTypeSell:
+		if i.Rate == nil || i.Amount == nil {
+			return fmt.Errorf("%w: rate and amount are required for a limit order", ErrInvalidOrder)
+		}
+		if i.MarketBuyAmount != nil {
+			return fmt.Errorf("%w: market_buy_amount can not be used with a limit order", ErrInvalidOrder)
+		}
+	case OrderTypeMarketBuy:
+		if i.MarketBuyAmount == nil {
+			return fmt.Errorf("%w: market_buy_amount is required for a market buy order", ErrInvalidOrder)
+		}
+		if i.Rate != nil || i.Amount != nil {
+			return fmt.Errorf("%w: rate and amount can not be used with a market buy order", ErrInvalidOrder)
+		}
+	case OrderTypeMarketSell:
+		if i.Amount == nil {
+			return fmt.Errorf("%w: amount is required for a market sell order", ErrInvalidOrder)
+		}
+		if i.Rate != nil || i.MarketBuyAmount != nil {
+			return fmt.Errorf("%w: rate and market_buy_amount can not be used with a market sell order", ErrInvalidOrder)
+		}
+	default:
+		return fmt.Errorf("%w: unknown order type %q", ErrInvalidOrder, i.OrderType)
+	}
+
+	switch i.TimeInForce {
+	case "", TimeInForceGoodTilCancelled:
+	case TimeInForcePostOnly:
+		if i.OrderType == OrderTypeMarketBuy || i.OrderType == OrderTypeMarketSell {
+			return fmt.Errorf("%w: post_only can not be used with a market order", ErrInvalidOrder)
+		}
+	default:
+		return fmt.Errorf("%w: unknown time in force %q", ErrInvalidOrder, i.TimeInForce)
+	}
+	return nil
+}
+
+// createOrderRequestBody is the request body for POST /api/exchange/orders.
+type createOrderRequestBody struct {
+	Pair            Pair        `json:"pair"`
+	OrderType       OrderType   `json:"order_type"`
+	Rate            string      `json:"rate,omitempty"`
+	Amount          string      `json:"amount,omitempty"`
+	MarketBuyAmount string      `json:"market_buy_amount,omitempty"`
+	StopLossRate    string      `json:"stop_loss_rate,omitempty"`
+	TimeInForce     TimeInForce `json:"time_in_force,omitempty"`
+}
+
+// decimalString returns the string representation of d. If d is nil, it returns an empty string.
+func decimalString(d *Decimal) string {
+	if d == nil {
+		return ""
+	}
+	return d.String()
+}
+
+// CreateOrderResponse represents the output from the CreateOrder method.
+type CreateOrderResponse struct {
+	// Success is a boolean value that indicates the success of the API call.
+	Success bool `json:"success"`
+	// ID is the order ID.
+	ID int `json:"id"`
+	// Rate is the order rate. It's 0 for market orders.
+	Rate Decimal `json:"rate"`
+	// Amount is the order amount. It's 0 for market buy orders.
+	Amount Decimal `json:"amount"`
+	// MarketBuyAmount is the market buy amount in JPY. It's 0 except for market buy orders.
+	MarketBuyAmount Decimal `json:"market_buy_amount"`
+	// OrderType is the order type.
+	OrderType OrderType `json:"order_type"`
+	// TimeInForce is the time in force of the order.
+	TimeInForce TimeInForce `json:"time_in_force"`
+	// StopLossRate is the stop loss rate. It's 0 if you don't set it.
+	StopLossRate Decimal `json:"stop_loss_rate"`
+	// Pair is the pair of the currency.
+	Pair Pair `json:"pair"`
+	// CreatedAt is the creation time of the order.
+	CreatedAt Time `json:"created_at"`
+}
+
+// CreateOrder creates a new order on the exchange.
+// API: POST /api/exchange/orders
+// Visibility: Private
+// The input is validated before sending the request, so mutually exclusive fields are
+// reported as ErrInvalidOrder without calling the Coincheck API.
+func (c *Client) CreateOrder(ctx context.Context, input CreateOrderInput) (*CreateOrderResponse, error) {
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+
+	req, err := c.createRequest(ctx, createRequestInput{
+		method: http.MethodPost,
+		path:   "/api/exchange/orders",
+		body: createOrderRequestBody{
+			Pair:            input.Pair,
+			OrderType:       input.OrderType,
+			Rate:            decimalString(input.Rate),
+			Amount:          decimalString(input.Amount),
+			MarketBuyAmount: decimalString(input.MarketBuyAmount),
+			StopLossRate:    decimalString(input.StopLossRate),
+			TimeInForce:     input.TimeInForce,
+		},
+		private: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var output CreateOrderResponse
+	if err := c.do(req, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// GetOpenOrdersResponse represents the output from the GetOpenOrders method.
+type GetOpenOrdersResponse struct {
+	// Success is a boolean value that indicates the success of the API call.
+	Success bool `json:"success"`
+	// Orders is a list of open orders.
+	Orders []OpenOrder `json:"orders"`
+}
+
+// OpenOrder represents an unsettled order.
+type OpenOrder struct {
+	// ID is the order ID.
+	ID int `json:"id"`
+	// OrderType is the order type (buy or sell).
+	OrderType OrderType `json:"order_type"`
+	// Rate is the order rate. It's 0 for market orders.
+	Rate Decimal `json:"rate"`
+	// Pair is the pair of the currency.
+	Pair Pair `json:"pair"`
+	// PendingAmount is the unsettled amount of the order.
+	PendingAmount Decimal `json:"pending_amount"`
+	// PendingMarketBuyAmount is the unsettled market buy amount in JPY. It's 0 except for market buy orders.
+	PendingMarketBuyAmount Decimal `json:"pending_market_buy_amount"`
+	// StopLossRate is the stop loss rate. It's 0 if the order does not have it.
+	StopLossRate Decimal `json:"stop_loss_rate"`
+	// CreatedAt is the creation time of the order.
+	CreatedAt Time `json:"created_at"`
+}
+
+// GetOpenOrders returns a list of your unsettled orders.
+// API: GET /api/exchange/orders/opens
+// Visibility: Private
+func (c *Client) GetOpenOrders(ctx context.Context) (*GetOpenOrdersResponse, error) {
+	req, err := c.createRequest(ctx, createRequestInput{
+		method:  http.MethodGet,
+		path:    "/api/exchange/orders/opens",
+		private: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var output GetOpenOrdersResponse
+	if err := c.do(req, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// CancelOrderInput represents the input parameter for the CancelOrder method.
+type CancelOrderInput struct {
+	// ID is the order ID to be cancelled. You can get it from CreateOrder or GetOpenOrders.
+	ID int
+}
+
+// CancelOrderResponse represents the output from the CancelOrder method.
+type CancelOrderResponse struct {
+	// Success is a boolean value that indicates the success of the API call.
+	Success bool `json:"success"`
+	// ID is the cancelled order ID.
+	ID int `json:"id"`
+}
+
+// CancelOrder cancels the order.
+// API: DELETE /api/exchange/orders/[id]
+// Visibility: Private
+// The cancellation is processed asynchronously. You can check it with GetCancelStatus.
+func (c *Client) CancelOrder(ctx context.Context, input CancelOrderInput) (*CancelOrderResponse, error) {
+	req, err := c.createRequest(ctx, createRequestInput{
+		method:  http.MethodDelete,
+		path:    "/api/exchange/orders/" + strconv.Itoa(input.ID),
+		private: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var output CancelOrderResponse
+	if err := c.do(req, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// GetCancelStatusInput represents the input parameter for the GetCancelStatus method.
+type GetCancelStatusInput struct {
+	// ID is the order ID.
+	ID int
+}
+
+// GetCancelStatusResponse represents the output from the GetCancelStatus method.
+type GetCancelStatusResponse struct {
+	// Success is a boolean value that indicates the success of the API call.
+	Success bool `json:"success"`
+	// ID is the order ID.
+	ID int `json:"id"`
+	// Cancel is true if the order has been cancelled.
+	Cancel bool `json:"cancel"`
+	// CreatedAt is the creation time of the order.
+	CreatedAt Time `json:"created_at"`
+}
+
+// GetCancelStatus returns the cancellation status of the order.
+// API: GET /api/exchange/orders/cancel_status
+// Visibility: Private
+func (c *Client) GetCancelStatus(ctx context.Context, input GetCancelStatusInput) (*GetCancelStatusResponse, error) {
+	req, err := c.createRequest(ctx, createRequestInput{
+		method: http.MethodGet,
+		path:   "/api/exchange/orders/cancel_status",
+		queryParam: map[string]string{
+			"id": strconv.Itoa(input.ID),
+		},
+		private: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var output GetCancelStatusResponse
+	if err := c.do(req, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// defaultCancelAllOrdersParallelism is the default number of orders cancelled at the same time.
+const defaultCancelAllOrdersParallelism = 4
+
+// CancelAllOrdersInput represents the input parameter for the CancelAllOrders method.
+type CancelAllOrdersInput struct {
+	// Pair is the pair of the currency. If it's empty, all open orders are cancelled.
+	Pair Pair
+	// Parallelism is the maximum number of orders cancelled at the same time.
+	// If it's 0 or less, 4 is used. Be careful about the rate limit of the Coincheck API.
+	Parallelism int
+}
+
+// CancelOrderResult represents the result of cancelling one order in CancelAllOrders.
+type CancelOrderResult struct {
+	// Order is the open order to be cancelled.
+	Order OpenOrder
+	// Err is the error returned by CancelOrder. It's nil if the order was cancelled.
+	Err error
+}
+
+// CancelAllOrdersResponse represents the output from the CancelAllOrders method.
+type CancelAllOrdersResponse struct {
+	// Results is the result of each order. The order is the same as GetOpenOrders.
+	Results []CancelOrderResult
+}
+
+// Failed returns the results of the orders that could not be cancelled.
+func (r *CancelAllOrdersResponse) Failed() []CancelOrderResult {
+	var failed []CancelOrderResult
+	for _, result := range r.Results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// CancelAllOrders cancels all open orders concurrently.
+// Visibility: Private
+// It lists open orders with GetOpenOrders, filters them by Pair, and cancels them with CancelOrder.
+// An error is returned only if the open orders can not be listed. The error of each cancellation
+// is reported in CancelAllOrdersResponse.Results.
+func (c *Client) CancelAllOrders(ctx context.Context, input CancelAllOrdersInput) (*CancelAllOrdersResponse, error) {
+	opens, err := c.GetOpenOrders(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]CancelOrderResult, 0, len(opens.Orders))
+	for _, order := range opens.Orders {
+		if input.Pair != "" && order.Pair != input.Pair {
+			continue
+		}
+		results = append(results, CancelOrderResult{Order: order})
+	}
+
+	parallelism := input.Parallelism
+	if parallelism <= 0 {
+		parallelism = defaultCancelAllOrdersParallelism
+	}
+	forEachLimited(len(results), parallelism, func(i int) {
+		_, results[i].Err = c.CancelOrder(ctx, CancelOrderInput{ID: results[i].Order.ID})
+	})
+
+	return &CancelAllOrdersResponse{Results: results}, nil
+}
+
+// OrderStatus represents the status of an order.
+type OrderStatus string
+
+// String returns the string representation of the OrderStatus.
+func (s OrderStatus) String() string {
+	return string(s)
+}
+
+const (
+	// OrderStatusNew means the order is open and not executed.
+	OrderStatusNew OrderStatus = "NEW"
+	// OrderStatusPartiallyFilled means the order is open and partially executed.
+	OrderStatusPartiallyFilled OrderStatus = "PARTIALLY_FILLED"
+	// OrderStatusFilled means the order was completely executed.
+	OrderStatusFilled OrderStatus = "FILLED"
+	// OrderStatusCanceled means the order was cancelled without any execution.
+	OrderStatusCanceled OrderStatus = "CANCELED"
+	// OrderStatusPartiallyFilledCanceled means the order was cancelled after a partial execution.
+	OrderStatusPartiallyFilledCanceled OrderStatus = "PARTIALLY_FILLED_CANCELED"
+	// OrderStatusExpired means the order was expired without any execution.
+	OrderStatusExpired OrderStatus = "EXPIRED"
+	// OrderStatusPartiallyFilledExpired means the order was expired after a partial execution.
+	OrderStatusPartiallyFilledExpired OrderStatus = "PARTIALLY_FILLED_EXPIRED"
+)
+
+// IsTerminal returns true if the order is closed, and its status never changes.
+func (s OrderStatus) IsTerminal() bool {
+	switch s {
+	case OrderStatusFilled, OrderStatusCanceled, OrderStatusPartiallyFilledCanceled,
+		OrderStatusExpired, OrderStatusPartiallyFilledExpired:
+		return true
+	default:
+		return false
+	}
+}
+
+// Order represents an order returned by GetOrder.
+// It shares the types of the fields with CreateOrderResponse, OpenOrder and OrderEvent.
+type Order struct {
+	// ID is the order ID. It's the same as CreateOrderResponse.ID and OpenOrder.ID.
+	ID int `json:"id"`
+	// Pair is the pair of the currency.
+	Pair Pair `json:"pair"`
+	// Status is the status of the order.
+	Status OrderStatus `json:"status"`
+	// OrderType is the order type.
+	OrderType OrderType `json:"order_type"`
+	// Rate is the order rate. It's 0 for market orders.
+	Rate Decimal `json:"rate"`
+	// StopLossRate is the stop loss rate. It's 0 if the order does not have it.
+	StopLossRate Decimal `json:"stop_loss_rate"`
+	// MakerFeeRate is the fee rate when the order is a maker.
+	MakerFeeRate Decimal `json:"maker_fee_rate"`
+	// TakerFeeRate is the fee rate when the order is a taker.
+	TakerFeeRate Decimal `json:"taker_fee_rate"`
+	// Amount is the order amount. It's 0 for market buy orders.
+	Amount Decimal `json:"amount"`
+	// MarketBuyAmount is the market buy amount in JPY. It's 0 except for market buy orders.
+	MarketBuyAmount Decimal `json:"market_buy_amount"`
+	// ExecutedAmount is the executed amount.
+	ExecutedAmount Decimal `json:"executed_amount"`
+	// ExecutedMarketBuyAmount is the executed market buy amount in JPY. It's 0 except for market buy orders.
+	ExecutedMarketBuyAmount Decimal `json:"executed_market_buy_amount"`
+	// ExpiredType is the reason of the expiry (e.g. self_trade_prevention). It's empty unless the order expired.
+	ExpiredType string `json:"expired_type"`
+	// PreventedMatchID is the ID of the order that the self trade prevention matched. It's 0 if not set.
+	PreventedMatchID int `json:"prevented_match_id"`
+	// ExpiredAmount is the expired amount.
+	ExpiredAmount Decimal `json:"expired_amount"`
+	// ExpiredMarketBuyAmount is the expired market buy amount in JPY.
+	ExpiredMarketBuyAmount Decimal `json:"expired_market_buy_amount"`
+	// TimeInForce is the time in force of the order.
+	TimeInForce TimeInForce `json:"time_in_force"`
+	// CreatedAt is the creation time of the order.
+	CreatedAt Time `json:"created_at"`
+}
+
+// AveragePrice returns the average executed price of the order, weighted by the amount of
+// the transactions (e.g. GetTransactions) of the order. The transactions of other orders are ignored.
+// It's rounded to 8 digits after the decimal point. It returns false if the order has no transaction.
+func (o Order) AveragePrice(transactions []Transaction) (Decimal, bool) {
+	var amount, notional Decimal
+	for _, tx := range transactions {
+		if tx.OrderID != o.ID {
+			continue
+		}
+		filled := tx.Funds[tx.Pair.Base()].Abs()
+		amount = amount.Add(filled)
+		notional = notional.Add(tx.Rate.Mul(filled))
+	}
+	if amount.IsZero() {
+		return Decimal{}, false
+	}
+	return notional.Div(amount, estimatePrecision), true
+}
+
+// GetOrderInput represents the input parameter for the GetOrder method.
+type GetOrderInput struct {
+	// ID is the order ID. You can get it from CreateOrder or GetOpenOrders.
+	ID int
+}
+
+// GetOrderResponse represents the output from the GetOrder method.
+type GetOrderResponse struct {
+	// Success is a boolean value that indicates the success of the API call.
+	Success bool `json:"success"`
+	Order
+}
+
+// GetOrder returns the order, including the closed ones.
+// API: GET /api/exchange/orders/[id]
+// Visibility: Private
+func (c *Client) GetOrder(ctx context.Context, input GetOrderInput) (*GetOrderResponse, error) {
+	req, err := c.createRequest(ctx, createRequestInput{
+		method:  http.MethodGet,
+		path:    "/api/exchange/orders/" + strconv.Itoa(input.ID),
+		private: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var output GetOrderResponse
+	if err := c.do(req, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+const (
+	// defaultWaitForOrderMinInterval is the default delay before the second GetOrder of WaitForOrder.
+	defaultWaitForOrderMinInterval = time.Second
+	// defaultWaitForOrderMaxInterval is the default maximum delay between GetOrder of WaitForOrder.
+	defaultWaitForOrderMaxInterval = 30 * time.Second
+)
+
+// WaitForOrderInput represents the input parameter for the WaitForOrder method.
+type WaitForOrderInput struct {
+	// ID is the order ID.
+	ID int
+	// MinInterval is the delay before the second GetOrder. If it's 0, 1 second is used.
+	MinInterval time.Duration
+	// MaxInterval is the maximum delay between GetOrder. If it's 0, 30 seconds is used.
+	// The delay doubles on each poll up to MaxInterval.
+	MaxInterval time.Duration
+}
+
+// WaitForOrder polls the order with GetOrder until its status is terminal (see OrderStatus.IsTerminal).
+// Visibility: Private
+// The delay between polls doubles from MinInterval up to MaxInterval. A rate limited poll (ErrRateLimited)
+// is retried, and any other error is returned immediately. If ctx is done before the order is closed,
+// it returns the last order (nil if none) and the error of ctx.
+// To stop at a deadline, use context.WithTimeout.
+func (c *Client) WaitForOrder(ctx context.Context, input WaitForOrderInput) (*GetOrderResponse, error) {
+	interval := input.MinInterval
+	if interval <= 0 {
+		interval = defaultWaitForOrderMinInterval
+	}
+	maxInterval := input.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = defaultWaitForOrderMaxInterval
+	}
+	if maxInterval < interval {
+		maxInterval = interval
+	}
+
+	var last *GetOrderResponse
+	for {
+		order, err := c.GetOrder(ctx, GetOrderInput{ID: input.ID})
+		switch {
+		case err == nil:
+			last = order
+			if order.Status.IsTerminal() {
+				return order, nil
+			}
+		case ctx.Err() != nil:
+			return last, withPrefixError(ctx.Err())
+		case !errors.Is(err, ErrRateLimited):
+			return last, err
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return last, withPrefixError(ctx.Err())
+		case <-timer.C:
+		}
+		if interval *= 2; interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
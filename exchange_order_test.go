@@ -0,0 +1,730 @@
+package coincheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shogo82148/pointer"
+)
+
+func TestClient_CreateOrder(t *testing.T) {
+	t.Run("CreateOrder creates a limit order", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			wantMethod := http.MethodPost
+			if diff := cmp.Diff(wantMethod, r.Method); diff != "" {
+				printDiff(t, diff)
+			}
+
+			wantEndpoint := "/api/exchange/orders"
+			if diff := cmp.Diff(wantEndpoint, r.URL.Path); diff != "" {
+				printDiff(t, diff)
+			}
+
+			for _, h := range []string{"ACCESS-KEY", "ACCESS-NONCE", "ACCESS-SIGNATURE"} {
+				if r.Header.Get(h) == "" {
+					t.Errorf("%s header is empty", h)
+				}
+			}
+
+			var gotBody map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+				t.Fatal(err)
+			}
+			wantBody := map[string]string{
+				"pair":           "btc_jpy",
+				"order_type":     "buy",
+				"rate":           "28000.5",
+				"amount":         "0.0123456789",
+				"stop_loss_rate": "27000",
+				"time_in_force":  "post_only",
+			}
+			if diff := cmp.Diff(wantBody, gotBody); diff != "" {
+				printDiff(t, diff)
+			}
+
+			result := CreateOrderResponse{
+				Success:      true,
+				ID:           12345,
+				Rate:         MustParseDecimal("28000.5"),
+				Amount:       MustParseDecimal("0.0123456789"),
+				OrderType:    OrderTypeBuy,
+				TimeInForce:  TimeInForcePostOnly,
+				StopLossRate: MustParseDecimal("27000.0"),
+				Pair:         PairBTCJPY,
+				CreatedAt:    NewTime(time.Date(2015, 1, 10, 5, 55, 38, 0, time.UTC)),
+			}
+			if err := json.NewEncoder(w).Encode(result); err != nil {
+				t.Fatal(err)
+			}
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := client.CreateOrder(context.Background(), CreateOrderInput{
+			Pair:         PairBTCJPY,
+			OrderType:    OrderTypeBuy,
+			Rate:         pointer.Ptr(MustParseDecimal("28000.5")),
+			Amount:       pointer.Ptr(MustParseDecimal("0.0123456789")),
+			StopLossRate: pointer.Ptr(MustParseDecimal("27000")),
+			TimeInForce:  TimeInForcePostOnly,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := &CreateOrderResponse{
+			Success:      true,
+			ID:           12345,
+			Rate:         MustParseDecimal("28000.5"),
+			Amount:       MustParseDecimal("0.0123456789"),
+			OrderType:    OrderTypeBuy,
+			TimeInForce:  TimeInForcePostOnly,
+			StopLossRate: MustParseDecimal("27000.0"),
+			Pair:         PairBTCJPY,
+			CreatedAt:    NewTime(time.Date(2015, 1, 10, 5, 55, 38, 0, time.UTC)),
+		}
+		if diff := cmp.Diff(want, got, equateTime); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("CreateOrder creates a market buy order", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var gotBody map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+				t.Fatal(err)
+			}
+			wantBody := map[string]string{
+				"pair":              "etc_jpy",
+				"order_type":        "market_buy",
+				"market_buy_amount": "10000",
+			}
+			if diff := cmp.Diff(wantBody, gotBody); diff != "" {
+				printDiff(t, diff)
+			}
+
+			result := CreateOrderResponse{
+				Success:         true,
+				ID:              12346,
+				MarketBuyAmount: MustParseDecimal("10000.0"),
+				OrderType:       OrderTypeMarketBuy,
+				Pair:            PairETCJPY,
+			}
+			if err := json.NewEncoder(w).Encode(result); err != nil {
+				t.Fatal(err)
+			}
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := client.CreateOrder(context.Background(), CreateOrderInput{
+			Pair:            PairETCJPY,
+			OrderType:       OrderTypeMarketBuy,
+			MarketBuyAmount: pointer.Ptr(MustParseDecimal("10000")),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := &CreateOrderResponse{
+			Success:         true,
+			ID:              12346,
+			MarketBuyAmount: MustParseDecimal("10000.0"),
+			OrderType:       OrderTypeMarketBuy,
+			Pair:            PairETCJPY,
+		}
+		if diff := cmp.Diff(want, got, equateTime); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("CreateOrder returns ErrInvalidOrder if the input is invalid", func(t *testing.T) {
+		client, err := NewClient(
+			WithBaseURL("https://example.com"),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tests := []struct {
+			name  string
+			input CreateOrderInput
+		}{
+			{
+				name:  "pair is empty",
+				input: CreateOrderInput{OrderType: OrderTypeBuy, Rate: pointer.Ptr(MustParseDecimal("1")), Amount: pointer.Ptr(MustParseDecimal("1"))},
+			},
+			{
+				name:  "unknown order type",
+				input: CreateOrderInput{Pair: PairBTCJPY, OrderType: "unknown"},
+			},
+			{
+				name:  "limit order without rate",
+				input: CreateOrderInput{Pair: PairBTCJPY, OrderType: OrderTypeSell, Amount: pointer.Ptr(MustParseDecimal("1"))},
+			},
+			{
+				name: "limit order with market buy amount",
+				input: CreateOrderInput{
+					Pair: PairBTCJPY, OrderType: OrderTypeBuy,
+					Rate: pointer.Ptr(MustParseDecimal("1")), Amount: pointer.Ptr(MustParseDecimal("1")), MarketBuyAmount: pointer.Ptr(MustParseDecimal("1")),
+				},
+			},
+			{
+				name:  "market buy order with amount",
+				input: CreateOrderInput{Pair: PairBTCJPY, OrderType: OrderTypeMarketBuy, MarketBuyAmount: pointer.Ptr(MustParseDecimal("1")), Amount: pointer.Ptr(MustParseDecimal("1"))},
+			},
+			{
+				name:  "market sell order without amount",
+				input: CreateOrderInput{Pair: PairBTCJPY, OrderType: OrderTypeMarketSell},
+			},
+			{
+				name:  "market sell order with rate",
+				input: CreateOrderInput{Pair: PairBTCJPY, OrderType: OrderTypeMarketSell, Amount: pointer.Ptr(MustParseDecimal("1")), Rate: pointer.Ptr(MustParseDecimal("1"))},
+			},
+			{
+				name: "market order with post only",
+				input: CreateOrderInput{
+					Pair: PairBTCJPY, OrderType: OrderTypeMarketSell, Amount: pointer.Ptr(MustParseDecimal("1")), TimeInForce: TimeInForcePostOnly,
+				},
+			},
+			{
+				name:  "negative amount",
+				input: CreateOrderInput{Pair: PairBTCJPY, OrderType: OrderTypeBuy, Rate: pointer.Ptr(MustParseDecimal("1")), Amount: pointer.Ptr(MustParseDecimal("-1"))},
+			},
+			{
+				name: "unknown time in force",
+				input: CreateOrderInput{
+					Pair: PairBTCJPY, OrderType: OrderTypeBuy, Rate: pointer.Ptr(MustParseDecimal("1")), Amount: pointer.Ptr(MustParseDecimal("1")), TimeInForce: "unknown",
+				},
+			},
+		}
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				if _, err := client.CreateOrder(context.Background(), tt.input); !errors.Is(err, ErrInvalidOrder) {
+					t.Errorf("error is not ErrInvalidOrder: %v", err)
+				}
+			})
+		}
+	})
+
+	t.Run("CreateOrder returns an error if client does not set credentials", func(t *testing.T) {
+		client, err := NewClient(WithBaseURL("https://example.com"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = client.CreateOrder(context.Background(), CreateOrderInput{
+			Pair:      PairBTCJPY,
+			OrderType: OrderTypeMarketSell,
+			Amount:    pointer.Ptr(MustParseDecimal("1")),
+		})
+		if !errors.Is(err, ErrNoCredentials) {
+			t.Errorf("error is not ErrNoCredentials: %v", err)
+		}
+	})
+}
+
+func TestClient_GetOpenOrders(t *testing.T) {
+	t.Run("GetOpenOrders returns a list of open orders", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			wantMethod := http.MethodGet
+			if diff := cmp.Diff(wantMethod, r.Method); diff != "" {
+				printDiff(t, diff)
+			}
+
+			wantEndpoint := "/api/exchange/orders/opens"
+			if diff := cmp.Diff(wantEndpoint, r.URL.Path); diff != "" {
+				printDiff(t, diff)
+			}
+
+			w.Write([]byte(`{
+				"success": true,
+				"orders": [
+					{
+						"id": 202835,
+						"order_type": "buy",
+						"rate": 26890,
+						"pair": "btc_jpy",
+						"pending_amount": "0.5527",
+						"pending_market_buy_amount": null,
+						"stop_loss_rate": null,
+						"created_at": "2015-01-10T05:55:38.000Z"
+					}
+				]
+			}`)) //nolint: errcheck // ignore error
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := client.GetOpenOrders(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := &GetOpenOrdersResponse{
+			Success: true,
+			Orders: []OpenOrder{
+				{
+					ID:            202835,
+					OrderType:     OrderTypeBuy,
+					Rate:          MustParseDecimal("26890"),
+					Pair:          PairBTCJPY,
+					PendingAmount: MustParseDecimal("0.5527"),
+					CreatedAt:     NewTime(time.Date(2015, 1, 10, 5, 55, 38, 0, time.UTC)),
+				},
+			},
+		}
+		if diff := cmp.Diff(want, got, equateTime); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("GetOpenOrders returns an error if client does not set credentials", func(t *testing.T) {
+		client, err := NewClient(WithBaseURL("https://example.com"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = client.GetOpenOrders(context.Background()); !errors.Is(err, ErrNoCredentials) {
+			t.Errorf("error is not ErrNoCredentials: %v", err)
+		}
+	})
+}
+
+func TestClient_CancelOrder(t *testing.T) {
+	t.Run("CancelOrder cancels the order", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			wantMethod := http.MethodDelete
+			if diff := cmp.Diff(wantMethod, r.Method); diff != "" {
+				printDiff(t, diff)
+			}
+
+			wantEndpoint := "/api/exchange/orders/12345"
+			if diff := cmp.Diff(wantEndpoint, r.URL.Path); diff != "" {
+				printDiff(t, diff)
+			}
+
+			result := CancelOrderResponse{
+				Success: true,
+				ID:      12345,
+			}
+			if err := json.NewEncoder(w).Encode(result); err != nil {
+				t.Fatal(err)
+			}
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := client.CancelOrder(context.Background(), CancelOrderInput{ID: 12345})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := &CancelOrderResponse{
+			Success: true,
+			ID:      12345,
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+}
+
+func TestClient_GetCancelStatus(t *testing.T) {
+	t.Run("GetCancelStatus returns the cancellation status", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			wantMethod := http.MethodGet
+			if diff := cmp.Diff(wantMethod, r.Method); diff != "" {
+				printDiff(t, diff)
+			}
+
+			wantEndpoint := "/api/exchange/orders/cancel_status"
+			if diff := cmp.Diff(wantEndpoint, r.URL.Path); diff != "" {
+				printDiff(t, diff)
+			}
+
+			if got := r.URL.Query().Get("id"); got != "12345" {
+				t.Errorf("id: got %v, want %v", got, "12345")
+			}
+
+			result := GetCancelStatusResponse{
+				Success:   true,
+				ID:        12345,
+				Cancel:    true,
+				CreatedAt: NewTime(time.Date(2020, 7, 29, 17, 9, 33, 0, time.UTC)),
+			}
+			if err := json.NewEncoder(w).Encode(result); err != nil {
+				t.Fatal(err)
+			}
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := client.GetCancelStatus(context.Background(), GetCancelStatusInput{ID: 12345})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := &GetCancelStatusResponse{
+			Success:   true,
+			ID:        12345,
+			Cancel:    true,
+			CreatedAt: NewTime(time.Date(2020, 7, 29, 17, 9, 33, 0, time.UTC)),
+		}
+		if diff := cmp.Diff(want, got, equateTime); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+}
+
+func TestClient_CancelAllOrders(t *testing.T) {
+	t.Run("CancelAllOrders cancels open orders of the pair and reports each result", func(t *testing.T) {
+		var (
+			mu        sync.Mutex
+			cancelled []string
+		)
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet {
+				result := GetOpenOrdersResponse{
+					Success: true,
+					Orders: []OpenOrder{
+						{ID: 1, OrderType: OrderTypeBuy, Pair: PairBTCJPY},
+						{ID: 2, OrderType: OrderTypeSell, Pair: PairETCJPY},
+						{ID: 3, OrderType: OrderTypeSell, Pair: PairBTCJPY},
+						{ID: 4, OrderType: OrderTypeSell, Pair: PairBTCJPY},
+					},
+				}
+				if err := json.NewEncoder(w).Encode(result); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			mu.Lock()
+			cancelled = append(cancelled, r.URL.Path)
+			mu.Unlock()
+
+			if r.URL.Path == "/api/exchange/orders/3" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"success":false,"error":"The order doesn't exist."}`)) //nolint: errcheck // ignore error
+				return
+			}
+			w.Write([]byte(`{"success":true,"id":1}`)) //nolint: errcheck // ignore error
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := client.CancelAllOrders(context.Background(), CancelAllOrdersInput{
+			Pair:        PairBTCJPY,
+			Parallelism: 2,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sort.Strings(cancelled)
+		wantCancelled := []string{
+			"/api/exchange/orders/1",
+			"/api/exchange/orders/3",
+			"/api/exchange/orders/4",
+		}
+		if diff := cmp.Diff(wantCancelled, cancelled); diff != "" {
+			printDiff(t, diff)
+		}
+
+		if diff := cmp.Diff(3, len(got.Results)); diff != "" {
+			printDiff(t, diff)
+		}
+		failed := got.Failed()
+		if len(failed) != 1 || failed[0].Order.ID != 3 {
+			t.Errorf("unexpected failed results: %+v", failed)
+		}
+	})
+
+	t.Run("CancelAllOrders returns an error if open orders can not be listed", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := client.CancelAllOrders(context.Background(), CancelAllOrdersInput{}); err == nil {
+			t.Error("want error, but got nil")
+		}
+	})
+}
+
+func TestClient_GetOrder(t *testing.T) {
+	t.Run("GetOrder returns the order", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			wantMethod := http.MethodGet
+			if diff := cmp.Diff(wantMethod, r.Method); diff != "" {
+				printDiff(t, diff)
+			}
+
+			wantEndpoint := "/api/exchange/orders/12345"
+			if diff := cmp.Diff(wantEndpoint, r.URL.Path); diff != "" {
+				printDiff(t, diff)
+			}
+
+			w.Write([]byte(`{
+				"success": true,
+				"id": 12345,
+				"pair": "btc_jpy",
+				"status": "PARTIALLY_FILLED_EXPIRED",
+				"order_type": "buy",
+				"rate": "0.1",
+				"stop_loss_rate": null,
+				"maker_fee_rate": "0.001",
+				"taker_fee_rate": "0.001",
+				"amount": "1.0",
+				"market_buy_amount": null,
+				"executed_amount": "0.4",
+				"executed_market_buy_amount": null,
+				"expired_type": "self_trade_prevention",
+				"prevented_match_id": 123,
+				"expired_amount": "0.6",
+				"expired_market_buy_amount": null,
+				"time_in_force": "good_til_cancelled",
+				"created_at": "2020-07-05T04:02:15.000Z"
+			}`)) //nolint: errcheck // ignore error
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := client.GetOrder(context.Background(), GetOrderInput{ID: 12345})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := &GetOrderResponse{
+			Success: true,
+			Order: Order{
+				ID:               12345,
+				Pair:             PairBTCJPY,
+				Status:           OrderStatusPartiallyFilledExpired,
+				OrderType:        OrderTypeBuy,
+				Rate:             MustParseDecimal("0.1"),
+				MakerFeeRate:     MustParseDecimal("0.001"),
+				TakerFeeRate:     MustParseDecimal("0.001"),
+				Amount:           MustParseDecimal("1.0"),
+				ExecutedAmount:   MustParseDecimal("0.4"),
+				ExpiredType:      "self_trade_prevention",
+				PreventedMatchID: 123,
+				ExpiredAmount:    MustParseDecimal("0.6"),
+				TimeInForce:      TimeInForceGoodTilCancelled,
+				CreatedAt:        NewTime(time.Date(2020, 7, 5, 4, 2, 15, 0, time.UTC)),
+			},
+		}
+		if diff := cmp.Diff(want, got, equateTime); diff != "" {
+			printDiff(t, diff)
+		}
+		if !got.Status.IsTerminal() {
+			t.Errorf("%s must be terminal", got.Status)
+		}
+	})
+}
+
+func TestOrderStatus_IsTerminal(t *testing.T) {
+	t.Parallel()
+
+	for _, status := range []OrderStatus{OrderStatusNew, OrderStatusPartiallyFilled} {
+		if status.IsTerminal() {
+			t.Errorf("%s must not be terminal", status)
+		}
+	}
+	for _, status := range []OrderStatus{
+		OrderStatusFilled, OrderStatusCanceled, OrderStatusPartiallyFilledCanceled,
+		OrderStatusExpired, OrderStatusPartiallyFilledExpired,
+	} {
+		if !status.IsTerminal() {
+			t.Errorf("%s must be terminal", status)
+		}
+	}
+}
+
+func TestOrder_AveragePrice(t *testing.T) {
+	t.Parallel()
+
+	order := Order{ID: 1}
+	transactions := []Transaction{
+		{OrderID: 1, Pair: PairBTCJPY, Rate: MustParseDecimal("4000000"), Funds: map[Currency]Decimal{CurrencyBTC: MustParseDecimal("0.1")}},
+		{OrderID: 1, Pair: PairBTCJPY, Rate: MustParseDecimal("4010000"), Funds: map[Currency]Decimal{CurrencyBTC: MustParseDecimal("0.3")}},
+		{OrderID: 2, Pair: PairBTCJPY, Rate: MustParseDecimal("1"), Funds: map[Currency]Decimal{CurrencyBTC: MustParseDecimal("10")}},
+	}
+	got, ok := order.AveragePrice(transactions)
+	if !ok {
+		t.Fatal("average price must exist")
+	}
+	if want := MustParseDecimal("4007500"); !got.Equal(want) {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	sold := []Transaction{
+		{OrderID: 1, Pair: PairBTCJPY, Rate: MustParseDecimal("4000000"), Funds: map[Currency]Decimal{CurrencyBTC: MustParseDecimal("-0.5")}},
+	}
+	if got, _ := order.AveragePrice(sold); !got.Equal(MustParseDecimal("4000000")) {
+		t.Errorf("got %s, want 4000000", got)
+	}
+
+	if _, ok := order.AveragePrice(transactions[2:]); ok {
+		t.Error("average price must not exist")
+	}
+}
+
+func TestClient_WaitForOrder(t *testing.T) {
+	t.Run("WaitForOrder polls the order until it's filled", func(t *testing.T) {
+		statuses := []string{"NEW", "", "PARTIALLY_FILLED", "FILLED"}
+		var (
+			mu    sync.Mutex
+			calls int
+		)
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			mu.Lock()
+			status := statuses[calls]
+			calls++
+			mu.Unlock()
+
+			if status == "" {
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.Write([]byte(`{"success":true,"id":1,"status":"` + status + `"}`)) //nolint: errcheck // ignore error
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := client.WaitForOrder(context.Background(), WaitForOrderInput{
+			ID:          1,
+			MinInterval: time.Millisecond,
+			MaxInterval: 2 * time.Millisecond,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Status != OrderStatusFilled {
+			t.Errorf("got %s, want FILLED", got.Status)
+		}
+		if calls != len(statuses) {
+			t.Errorf("got %d calls, want %d", calls, len(statuses))
+		}
+	})
+
+	t.Run("WaitForOrder returns the last order when the context is done", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Write([]byte(`{"success":true,"id":1,"status":"NEW"}`)) //nolint: errcheck // ignore error
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		got, err := client.WaitForOrder(ctx, WaitForOrderInput{ID: 1, MinInterval: 5 * time.Millisecond})
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("error is not context.DeadlineExceeded: %v", err)
+		}
+		if got == nil || got.Status != OrderStatusNew {
+			t.Errorf("want the last order, got %+v", got)
+		}
+	})
+
+	t.Run("WaitForOrder returns an error other than the rate limit immediately", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := client.WaitForOrder(context.Background(), WaitForOrderInput{ID: 1}); !errors.Is(err, ErrUnauthorized) {
+			t.Errorf("error is not ErrUnauthorized: %v", err)
+		}
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/shogo82148/pointer"
@@ -29,7 +30,7 @@ func TestClient_GetExchangeStatus(t *testing.T) {
 					{
 						Pair:      PairBTCJPY,
 						Status:    ExchangeStatusAvailabilityAvailable,
-						Timestamp: 1609459200,
+						Timestamp: NewTime(time.Unix(1609459200, 0)),
 						Availability: Availability{
 							Order:       true,
 							MarketOrder: true,
@@ -39,7 +40,7 @@ func TestClient_GetExchangeStatus(t *testing.T) {
 					{
 						Pair:      PairBrilJPY,
 						Status:    ExchangeStatusAvailabilityItayose,
-						Timestamp: 1609459200,
+						Timestamp: NewTime(time.Unix(1609459200, 0)),
 						Availability: Availability{
 							Order:       false,
 							MarketOrder: false,
@@ -67,7 +68,7 @@ func TestClient_GetExchangeStatus(t *testing.T) {
 				{
 					Pair:      PairBTCJPY,
 					Status:    ExchangeStatusAvailabilityAvailable,
-					Timestamp: 1609459200,
+					Timestamp: NewTime(time.Unix(1609459200, 0)),
 					Availability: Availability{
 						Order:       true,
 						MarketOrder: true,
@@ -77,7 +78,7 @@ func TestClient_GetExchangeStatus(t *testing.T) {
 				{
 					Pair:      PairBrilJPY,
 					Status:    ExchangeStatusAvailabilityItayose,
-					Timestamp: 1609459200,
+					Timestamp: NewTime(time.Unix(1609459200, 0)),
 					Availability: Availability{
 						Order:       false,
 						MarketOrder: false,
@@ -86,7 +87,7 @@ func TestClient_GetExchangeStatus(t *testing.T) {
 				},
 			},
 		}
-		if diff := cmp.Diff(want, got); diff != "" {
+		if diff := cmp.Diff(want, got, equateTime); diff != "" {
 			printDiff(t, diff)
 		}
 	})
@@ -113,7 +114,7 @@ func TestClient_GetExchangeStatus(t *testing.T) {
 					{
 						Pair:      PairETCJPY,
 						Status:    ExchangeStatusAvailabilityAvailable,
-						Timestamp: 1609459200,
+						Timestamp: NewTime(time.Unix(1609459200, 0)),
 						Availability: Availability{
 							Order:       true,
 							MarketOrder: true,
@@ -143,7 +144,7 @@ func TestClient_GetExchangeStatus(t *testing.T) {
 				{
 					Pair:      PairETCJPY,
 					Status:    ExchangeStatusAvailabilityAvailable,
-					Timestamp: 1609459200,
+					Timestamp: NewTime(time.Unix(1609459200, 0)),
 					Availability: Availability{
 						Order:       true,
 						MarketOrder: true,
@@ -152,7 +153,7 @@ func TestClient_GetExchangeStatus(t *testing.T) {
 				},
 			},
 		}
-		if diff := cmp.Diff(want, got); diff != "" {
+		if diff := cmp.Diff(want, got, equateTime); diff != "" {
 			printDiff(t, diff)
 		}
 	})
@@ -6,3 +6,5 @@ require (
 	github.com/google/go-cmp v0.6.0
 	github.com/shogo82148/pointer v1.3.0
 )
+
+require github.com/gorilla/websocket v1.5.3
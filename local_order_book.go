@@ -0,0 +1,447 @@
+package coincheck
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultOrderBookMaxStaleness is the default maximum time without any update of the local order book.
+const defaultOrderBookMaxStaleness = time.Minute
+
+// OrderBookInput represents the input parameter for NewOrderBook.
+type OrderBookInput struct {
+	// Pair is the pair of the currency. If it's empty, btc_jpy is used.
+	Pair Pair
+	// MaxStaleness is the maximum time without any update. If the order book is not updated for longer
+	// than MaxStaleness, it's synchronized with GetOrderBooks again. If it's 0, 1 minute is used.
+	MaxStaleness time.Duration
+	// OnError is called when the order book can not be synchronized, or a difference is dropped
+	// as outdated (ErrOrderBookOutOfSync). Run keeps running. It may be nil.
+	OnError func(error)
+	// Stream configures the reconnection and the liveness detection of the stream used by Run.
+	Stream StreamConfig
+}
+
+// BestPriceEvent is sent to the subscribers of OrderBook when the best bid or the best ask price changes.
+// A zero PriceLevel means the side of the order book is empty.
+type BestPriceEvent struct {
+	// Pair is the pair of the currency.
+	Pair Pair
+	// BestBid is the highest bid.
+	BestBid PriceLevel
+	// BestAsk is the lowest ask.
+	BestAsk PriceLevel
+}
+
+// OrderBook is an order book of a pair maintained in memory.
+// It's seeded by GetOrderBooks, and updated by the differences of the "[pair]-orderbook" channel.
+// If the order book is stale or crossed (best bid >= best ask), it's synchronized with GetOrderBooks again.
+//
+// The differences applied while a snapshot is being fetched are replayed on top of the snapshot,
+// and a difference whose LastUpdateAt is older than the snapshot is dropped, because the snapshot
+// should already reflect it. The snapshot time is the local time when it's requested, so a dropped
+// difference may also mean the local clock is ahead. Because it can't be told apart, the order book
+// is marked stale when a difference is dropped, and Run synchronizes it again.
+//
+// All methods are safe for concurrent use.
+type OrderBook struct {
+	client       *Client
+	pair         Pair
+	maxStaleness time.Duration
+	onError      func(error)
+	streamConfig StreamConfig
+
+	// syncMu serializes Sync.
+	syncMu sync.Mutex
+
+	// mu protects the fields below.
+	mu sync.RWMutex
+	// bids and asks are the price levels keyed by priceKey.
+	bids map[string]PriceLevel
+	asks map[string]PriceLevel
+	// updatedAt is the local time of the last snapshot or difference.
+	updatedAt time.Time
+	// synced is true if the order book is seeded by a snapshot.
+	synced bool
+	// snapshotAt is the time of the last snapshot. LastUpdateAt of the Coincheck API is in seconds,
+	// so it's truncated to seconds, and a difference in the same second is applied.
+	snapshotAt time.Time
+	// syncing is true while a snapshot is being fetched.
+	syncing bool
+	// needsResync is true if a difference was dropped as outdated after the last snapshot.
+	needsResync bool
+	// pending is the differences applied while syncing. They are replayed on top of the snapshot.
+	pending []OrderBookEvent
+	// bestBid and bestAsk are the best prices that the subscribers were notified of.
+	bestBid PriceLevel
+	bestAsk PriceLevel
+	// seq is the sequence number of the last change of the best prices.
+	seq uint64
+
+	// pubMu serializes the calls of the subscribers, and protects published.
+	pubMu sync.Mutex
+	// published is the sequence number of the last event delivered to the subscribers.
+	published uint64
+
+	// subMu protects subscribers and nextID.
+	subMu       sync.Mutex
+	subscribers map[int]func(BestPriceEvent)
+	nextID      int
+}
+
+// NewOrderBook returns a new OrderBook. It's empty until Sync or Run is called.
+func (c *Client) NewOrderBook(input OrderBookInput) *OrderBook {
+	pair := input.Pair
+	if pair == "" {
+		pair = PairBTCJPY
+	}
+	maxStaleness := input.MaxStaleness
+	if maxStaleness <= 0 {
+		maxStaleness = defaultOrderBookMaxStaleness
+	}
+	return &OrderBook{
+		client:       c,
+		pair:         pair,
+		maxStaleness: maxStaleness,
+		onError:      input.OnError,
+		streamConfig: input.Stream,
+		bids:         map[string]PriceLevel{},
+		asks:         map[string]PriceLevel{},
+		subscribers:  map[int]func(BestPriceEvent){},
+	}
+}
+
+// Pair returns the pair of the order book.
+func (b *OrderBook) Pair() Pair {
+	return b.pair
+}
+
+// Run keeps the order book up to date until the context is cancelled.
+// It subscribes to the "[pair]-orderbook" channel, and synchronizes the order book with GetOrderBooks
+// every time the stream connects (so that the differences lost while disconnected are recovered),
+// when the order book is crossed, and when it's not updated for MaxStaleness.
+// It returns ctx.Err() if the context is cancelled, or the error of the stream if it gives up reconnecting.
+func (b *OrderBook) Run(ctx context.Context) error {
+	config := b.streamConfig
+	onStateChange := config.OnStateChange
+	config.OnStateChange = func(e StreamStateEvent) {
+		if e.State == StreamStateConnected {
+			// The stream has subscribed but not read any message yet,
+			// so the differences after the snapshot are applied in order.
+			b.reportError(b.Sync(ctx))
+		}
+		if onStateChange != nil {
+			onStateChange(e)
+		}
+	}
+
+	s := b.client.NewPublicStream(PublicStreamInput{
+		OnOrderBook: func(e OrderBookEvent) {
+			b.reportError(b.Apply(ctx, e))
+		},
+		OnError: b.onError,
+		Config:  config,
+	})
+	if err := s.SubscribeOrderBook(b.pair); err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go b.watchStaleness(ctx, done)
+
+	return s.Run(ctx)
+}
+
+// watchStaleness synchronizes the order book if it's not updated for maxStaleness.
+func (b *OrderBook) watchStaleness(ctx context.Context, done <-chan struct{}) {
+	ticker := time.NewTicker(b.maxStaleness / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-done:
+			return
+		case <-ticker.C:
+			if b.Stale() {
+				b.reportError(b.Sync(ctx))
+			}
+		}
+	}
+}
+
+// reportError calls OnError if err is not nil.
+func (b *OrderBook) reportError(err error) {
+	if err != nil && b.onError != nil {
+		b.onError(err)
+	}
+}
+
+// Sync replaces the order book with the snapshot returned by GetOrderBooks.
+// The differences applied while the snapshot is being fetched are replayed on top of it.
+func (b *OrderBook) Sync(ctx context.Context) error {
+	b.syncMu.Lock()
+	defer b.syncMu.Unlock()
+
+	b.mu.Lock()
+	b.syncing = true
+	b.pending = nil
+	b.mu.Unlock()
+
+	requestedAt := time.Now()
+	snapshot, err := b.client.GetOrderBooks(ctx, GetOrderBooksInput{Pair: b.pair})
+
+	b.mu.Lock()
+	pending := b.pending
+	b.syncing = false
+	b.pending = nil
+	if err != nil {
+		// The pending differences are already applied to the current order book.
+		b.mu.Unlock()
+		return err
+	}
+
+	b.bids = levelMap(snapshot.Bids)
+	b.asks = levelMap(snapshot.Asks)
+	b.snapshotAt = requestedAt.Truncate(time.Second)
+	b.synced = true
+	b.needsResync = false
+	for _, e := range pending {
+		if b.outdatedLocked(e) {
+			b.needsResync = true
+			continue
+		}
+		applyLevels(b.bids, e.Bids)
+		applyLevels(b.asks, e.Asks)
+	}
+	b.updatedAt = time.Now()
+	event, seq, changed := b.bestPriceChangedLocked()
+	b.mu.Unlock()
+
+	if changed {
+		b.publish(event, seq)
+	}
+	return nil
+}
+
+// Apply applies a difference of the "[pair]-orderbook" channel. A level whose amount is 0 is removed.
+// The differences of other pairs are ignored. A difference older than the last snapshot is dropped,
+// the order book is marked stale, and ErrOrderBookOutOfSync is returned.
+// If the order book is crossed after applying the difference, it's synchronized with GetOrderBooks.
+// While a snapshot is being fetched, the difference is also kept to be replayed on top of the snapshot.
+func (b *OrderBook) Apply(ctx context.Context, event OrderBookEvent) error {
+	if event.Pair != "" && event.Pair != b.pair {
+		return nil
+	}
+
+	b.mu.Lock()
+	if b.outdatedLocked(event) {
+		b.needsResync = true
+		snapshotAt := b.snapshotAt
+		b.mu.Unlock()
+		return fmt.Errorf("%w: difference at %s is older than the snapshot at %s",
+			ErrOrderBookOutOfSync, event.LastUpdateAt.Format(time.RFC3339), snapshotAt.Format(time.RFC3339))
+	}
+	applyLevels(b.bids, event.Bids)
+	applyLevels(b.asks, event.Asks)
+	b.updatedAt = time.Now()
+	syncing := b.syncing
+	if syncing {
+		b.pending = append(b.pending, event)
+	}
+	crossed := b.crossedLocked()
+	var (
+		best    BestPriceEvent
+		seq     uint64
+		changed bool
+	)
+	if !crossed {
+		best, seq, changed = b.bestPriceChangedLocked()
+	}
+	b.mu.Unlock()
+
+	if crossed {
+		if syncing {
+			// The snapshot being fetched replaces the crossed order book.
+			return nil
+		}
+		return b.Sync(ctx)
+	}
+	if changed {
+		b.publish(best, seq)
+	}
+	return nil
+}
+
+// Subscribe registers fn to be called when the best bid or the best ask price changes.
+// fn is called from the goroutine that updates the order book, so it should return quickly.
+// The calls of the subscribers are serialized, and the events are delivered in the order of the changes.
+// If a newer event has already been delivered, an older one is dropped. fn must not call Apply or Sync.
+// It returns a function to unsubscribe.
+func (b *OrderBook) Subscribe(fn func(BestPriceEvent)) (unsubscribe func()) {
+	b.subMu.Lock()
+	defer b.subMu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	b.subscribers[id] = fn
+	return func() {
+		b.subMu.Lock()
+		defer b.subMu.Unlock()
+		delete(b.subscribers, id)
+	}
+}
+
+// publish calls the subscribers with the event of the sequence number seq.
+// The event is dropped if a newer event has already been delivered, because Apply and Sync
+// can publish from different goroutines after releasing b.mu.
+func (b *OrderBook) publish(event BestPriceEvent, seq uint64) {
+	b.pubMu.Lock()
+	defer b.pubMu.Unlock()
+	if seq <= b.published {
+		return
+	}
+	b.published = seq
+
+	b.subMu.Lock()
+	subscribers := make([]func(BestPriceEvent), 0, len(b.subscribers))
+	for _, fn := range b.subscribers {
+		subscribers = append(subscribers, fn)
+	}
+	b.subMu.Unlock()
+
+	for _, fn := range subscribers {
+		fn(event)
+	}
+}
+
+// BestBid returns the highest bid. It returns false if there is no bid.
+func (b *OrderBook) BestBid() (PriceLevel, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return bestLevel(b.bids, Decimal.GreaterThan)
+}
+
+// BestAsk returns the lowest ask. It returns false if there is no ask.
+func (b *OrderBook) BestAsk() (PriceLevel, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return bestLevel(b.asks, Decimal.LessThan)
+}
+
+// Snapshot returns a copy of the order book with at most depth levels on each side, best price first.
+// If depth is 0 or less, all levels are returned. The analytics of GetOrderBooksResponse
+// (e.g. MidPrice, VWAP) can be used on the snapshot.
+func (b *OrderBook) Snapshot(depth int) *GetOrderBooksResponse {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return &GetOrderBooksResponse{
+		Asks: sortedLevels(b.asks, Decimal.LessThan, depth),
+		Bids: sortedLevels(b.bids, Decimal.GreaterThan, depth),
+	}
+}
+
+// UpdatedAt returns the local time of the last snapshot or difference.
+// It's zero if the order book has never been synchronized.
+func (b *OrderBook) UpdatedAt() time.Time {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.updatedAt
+}
+
+// Stale returns true if the order book has never been synchronized, it's not updated for MaxStaleness,
+// or a difference was dropped as outdated after the last snapshot.
+func (b *OrderBook) Stale() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return !b.synced || b.needsResync || time.Since(b.updatedAt) > b.maxStaleness
+}
+
+// outdatedLocked returns true if the difference is older than the last snapshot,
+// so the snapshot already reflects it. A difference without LastUpdateAt is never outdated. b.mu must be held.
+func (b *OrderBook) outdatedLocked(event OrderBookEvent) bool {
+	return b.synced && !event.LastUpdateAt.IsZero() && event.LastUpdateAt.Before(b.snapshotAt)
+}
+
+// crossedLocked returns true if the best bid is greater than or equal to the best ask. b.mu must be held.
+func (b *OrderBook) crossedLocked() bool {
+	bid, okBid := bestLevel(b.bids, Decimal.GreaterThan)
+	ask, okAsk := bestLevel(b.asks, Decimal.LessThan)
+	return okBid && okAsk && !bid.Price.LessThan(ask.Price)
+}
+
+// bestPriceChangedLocked updates the notified best prices, and returns true if one of them changed
+// with the sequence number of the change. b.mu must be held.
+func (b *OrderBook) bestPriceChangedLocked() (BestPriceEvent, uint64, bool) {
+	bid, _ := bestLevel(b.bids, Decimal.GreaterThan)
+	ask, _ := bestLevel(b.asks, Decimal.LessThan)
+	changed := !bid.Price.Equal(b.bestBid.Price) || !ask.Price.Equal(b.bestAsk.Price)
+	b.bestBid, b.bestAsk = bid, ask
+	if changed {
+		b.seq++
+	}
+	return BestPriceEvent{Pair: b.pair, BestBid: bid, BestAsk: ask}, b.seq, changed
+}
+
+// priceKey returns the key of the price level. e.g. "148634.0" and "148634" have the same key.
+func priceKey(price Decimal) string {
+	s := price.String()
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
+
+// levelMap returns the levels with a positive amount keyed by priceKey.
+func levelMap(levels []PriceLevel) map[string]PriceLevel {
+	m := make(map[string]PriceLevel, len(levels))
+	applyLevels(m, levels)
+	return m
+}
+
+// applyLevels sets the levels to m. A level whose amount is 0 is removed.
+func applyLevels(m map[string]PriceLevel, levels []PriceLevel) {
+	for _, l := range levels {
+		key := priceKey(l.Price)
+		if l.Amount.Sign() <= 0 {
+			delete(m, key)
+			continue
+		}
+		m[key] = l
+	}
+}
+
+// bestLevel returns the best level of m. better(a, b) returns true if the price a is better than b.
+func bestLevel(m map[string]PriceLevel, better func(a, b Decimal) bool) (PriceLevel, bool) {
+	var (
+		best  PriceLevel
+		found bool
+	)
+	for _, l := range m {
+		if !found || better(l.Price, best.Price) {
+			best, found = l, true
+		}
+	}
+	return best, found
+}
+
+// sortedLevels returns at most depth levels of m, best price first.
+func sortedLevels(m map[string]PriceLevel, better func(a, b Decimal) bool, depth int) []PriceLevel {
+	levels := make([]PriceLevel, 0, len(m))
+	for _, l := range m {
+		levels = append(levels, l)
+	}
+	sort.Slice(levels, func(i, j int) bool {
+		return better(levels[i].Price, levels[j].Price)
+	})
+	if depth > 0 && len(levels) > depth {
+		levels = levels[:depth]
+	}
+	return levels
+}
@@ -0,0 +1,402 @@
+package coincheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/websocket"
+)
+
+// newOrderBooksServer returns a test server that serves the snapshot as GET /api/order_books,
+// and counts the requests.
+func newOrderBooksServer(t *testing.T, snapshot GetOrderBooksResponse, requests *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if got := r.URL.Query().Get("pair"); got != PairETCJPY.String() {
+			t.Errorf("pair: got %v, want %v", got, PairETCJPY)
+		}
+		if err := json.NewEncoder(w).Encode(snapshot); err != nil {
+			t.Error(err)
+		}
+	}))
+}
+
+// testOrderBookSnapshot returns the order book served by newOrderBooksServer in the tests.
+func testOrderBookSnapshot() GetOrderBooksResponse {
+	return GetOrderBooksResponse{
+		Asks: []PriceLevel{
+			{Price: MustParseDecimal("3010"), Amount: MustParseDecimal("1")},
+			{Price: MustParseDecimal("3020"), Amount: MustParseDecimal("2")},
+		},
+		Bids: []PriceLevel{
+			{Price: MustParseDecimal("3000"), Amount: MustParseDecimal("1")},
+			{Price: MustParseDecimal("2990"), Amount: MustParseDecimal("3")},
+		},
+	}
+}
+
+func TestOrderBook(t *testing.T) {
+	t.Run("OrderBook applies the differences to the snapshot", func(t *testing.T) {
+		var requests atomic.Int32
+		testServer := newOrderBooksServer(t, testOrderBookSnapshot(), &requests)
+		defer testServer.Close()
+
+		client, err := NewClient(WithBaseURL(testServer.URL))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		book := client.NewOrderBook(OrderBookInput{Pair: PairETCJPY})
+		if !book.Stale() {
+			t.Error("OrderBook must be stale before Sync")
+		}
+
+		var events []BestPriceEvent
+		unsubscribe := book.Subscribe(func(e BestPriceEvent) {
+			events = append(events, e)
+		})
+
+		ctx := context.Background()
+		if err := book.Sync(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if book.Stale() {
+			t.Error("OrderBook must not be stale after Sync")
+		}
+
+		// The amount of 3010.0 is changed, 3000 is removed and 3005 is added.
+		if err := book.Apply(ctx, OrderBookEvent{
+			Pair: PairETCJPY,
+			Bids: []PriceLevel{
+				{Price: MustParseDecimal("3000.0"), Amount: MustParseDecimal("0")},
+				{Price: MustParseDecimal("3005"), Amount: MustParseDecimal("0.5")},
+			},
+			Asks: []PriceLevel{{Price: MustParseDecimal("3010.0"), Amount: MustParseDecimal("4")}},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		// The amount of the best ask is changed, but the best prices are not.
+		if err := book.Apply(ctx, OrderBookEvent{
+			Pair: PairETCJPY,
+			Asks: []PriceLevel{{Price: MustParseDecimal("3010"), Amount: MustParseDecimal("5")}},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		// The difference of another pair is ignored.
+		if err := book.Apply(ctx, OrderBookEvent{
+			Pair: PairBTCJPY,
+			Bids: []PriceLevel{{Price: MustParseDecimal("9000000"), Amount: MustParseDecimal("1")}},
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		want := &GetOrderBooksResponse{
+			Asks: []PriceLevel{
+				{Price: MustParseDecimal("3010"), Amount: MustParseDecimal("5")},
+				{Price: MustParseDecimal("3020"), Amount: MustParseDecimal("2")},
+			},
+			Bids: []PriceLevel{
+				{Price: MustParseDecimal("3005"), Amount: MustParseDecimal("0.5")},
+				{Price: MustParseDecimal("2990"), Amount: MustParseDecimal("3")},
+			},
+		}
+		if diff := cmp.Diff(want, book.Snapshot(0)); diff != "" {
+			printDiff(t, diff)
+		}
+
+		wantTop := &GetOrderBooksResponse{Asks: want.Asks[:1], Bids: want.Bids[:1]}
+		if diff := cmp.Diff(wantTop, book.Snapshot(1)); diff != "" {
+			printDiff(t, diff)
+		}
+
+		bid, _ := book.BestBid()
+		ask, _ := book.BestAsk()
+		if diff := cmp.Diff(want.Bids[0], bid); diff != "" {
+			printDiff(t, diff)
+		}
+		if diff := cmp.Diff(want.Asks[0], ask); diff != "" {
+			printDiff(t, diff)
+		}
+
+		wantEvents := []BestPriceEvent{
+			{
+				Pair:    PairETCJPY,
+				BestBid: PriceLevel{Price: MustParseDecimal("3000"), Amount: MustParseDecimal("1")},
+				BestAsk: PriceLevel{Price: MustParseDecimal("3010"), Amount: MustParseDecimal("1")},
+			},
+			{
+				Pair:    PairETCJPY,
+				BestBid: PriceLevel{Price: MustParseDecimal("3005"), Amount: MustParseDecimal("0.5")},
+				BestAsk: PriceLevel{Price: MustParseDecimal("3010.0"), Amount: MustParseDecimal("4")},
+			},
+		}
+		if diff := cmp.Diff(wantEvents, events); diff != "" {
+			printDiff(t, diff)
+		}
+
+		unsubscribe()
+		if err := book.Apply(ctx, OrderBookEvent{
+			Bids: []PriceLevel{{Price: MustParseDecimal("3006"), Amount: MustParseDecimal("1")}},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if len(events) != 2 {
+			t.Errorf("unsubscribed function must not be called: %v", events)
+		}
+	})
+
+	t.Run("OrderBook synchronizes again if it's crossed", func(t *testing.T) {
+		var requests atomic.Int32
+		testServer := newOrderBooksServer(t, testOrderBookSnapshot(), &requests)
+		defer testServer.Close()
+
+		client, err := NewClient(WithBaseURL(testServer.URL))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		book := client.NewOrderBook(OrderBookInput{Pair: PairETCJPY})
+		ctx := context.Background()
+		if err := book.Sync(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if err := book.Apply(ctx, OrderBookEvent{
+			Bids: []PriceLevel{{Price: MustParseDecimal("3010"), Amount: MustParseDecimal("1")}},
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(int32(2), requests.Load()); diff != "" {
+			printDiff(t, diff)
+		}
+		want := testOrderBookSnapshot()
+		if diff := cmp.Diff(&want, book.Snapshot(0)); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("OrderBook replays the differences applied while the snapshot is being fetched", func(t *testing.T) {
+		requested := make(chan struct{})
+		release := make(chan struct{})
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			close(requested)
+			<-release
+			if err := json.NewEncoder(w).Encode(testOrderBookSnapshot()); err != nil {
+				t.Error(err)
+			}
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(WithBaseURL(testServer.URL))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		book := client.NewOrderBook(OrderBookInput{Pair: PairETCJPY})
+		ctx := context.Background()
+		synced := make(chan error, 1)
+		go func() {
+			synced <- book.Sync(ctx)
+		}()
+
+		// The difference arrives after the snapshot is requested, so the snapshot does not reflect it.
+		<-requested
+		if err := book.Apply(ctx, OrderBookEvent{
+			Bids:         []PriceLevel{{Price: MustParseDecimal("3005"), Amount: MustParseDecimal("0.5")}},
+			LastUpdateAt: NewTime(time.Now()),
+		}); err != nil {
+			t.Fatal(err)
+		}
+		close(release)
+		if err := <-synced; err != nil {
+			t.Fatal(err)
+		}
+
+		want := testOrderBookSnapshot()
+		want.Bids = append([]PriceLevel{{Price: MustParseDecimal("3005"), Amount: MustParseDecimal("0.5")}}, want.Bids...)
+		if diff := cmp.Diff(&want, book.Snapshot(0)); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("OrderBook is marked stale if a difference after the snapshot has an earlier timestamp", func(t *testing.T) {
+		var requests atomic.Int32
+		testServer := newOrderBooksServer(t, testOrderBookSnapshot(), &requests)
+		defer testServer.Close()
+
+		client, err := NewClient(WithBaseURL(testServer.URL))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		book := client.NewOrderBook(OrderBookInput{Pair: PairETCJPY})
+		ctx := context.Background()
+		if err := book.Sync(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		// The difference arrives after the snapshot, but its timestamp is earlier than the snapshot
+		// (e.g. the local clock is ahead), so it's dropped and the order book needs to be synchronized.
+		err = book.Apply(ctx, OrderBookEvent{
+			Asks:         []PriceLevel{{Price: MustParseDecimal("3015"), Amount: MustParseDecimal("1")}},
+			LastUpdateAt: NewTime(time.Now().Add(-time.Minute)),
+		})
+		if !errors.Is(err, ErrOrderBookOutOfSync) {
+			t.Errorf("error is not ErrOrderBookOutOfSync: %v", err)
+		}
+		want := testOrderBookSnapshot()
+		if diff := cmp.Diff(&want, book.Snapshot(0)); diff != "" {
+			printDiff(t, diff)
+		}
+		if !book.Stale() {
+			t.Error("OrderBook must be stale after a difference is dropped")
+		}
+
+		if err := book.Sync(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if book.Stale() {
+			t.Error("OrderBook must not be stale after Sync")
+		}
+	})
+
+	t.Run("OrderBook delivers the best prices to the subscribers one at a time and in order", func(t *testing.T) {
+		var requests atomic.Int32
+		testServer := newOrderBooksServer(t, testOrderBookSnapshot(), &requests)
+		defer testServer.Close()
+
+		client, err := NewClient(WithBaseURL(testServer.URL))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		book := client.NewOrderBook(OrderBookInput{Pair: PairETCJPY})
+		ctx := context.Background()
+		if err := book.Sync(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		var (
+			inFlight atomic.Int32
+			last     BestPriceEvent
+		)
+		book.Subscribe(func(e BestPriceEvent) {
+			if inFlight.Add(1) != 1 {
+				t.Error("subscribers must not be called concurrently")
+			}
+			time.Sleep(time.Millisecond)
+			last = e
+			inFlight.Add(-1)
+		})
+
+		var wg sync.WaitGroup
+		for i := 1; i <= 20; i++ {
+			price := NewDecimal(int64(3000), 0).Add(NewDecimal(int64(i), 2))
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := book.Apply(ctx, OrderBookEvent{
+					Bids: []PriceLevel{{Price: price, Amount: MustParseDecimal("1")}},
+				}); err != nil {
+					t.Error(err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		// The last delivered event is the latest best prices, not an older one delivered late.
+		bid, _ := book.BestBid()
+		if diff := cmp.Diff(bid, last.BestBid); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("OrderBook returns an error if the snapshot can not be fetched", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(WithBaseURL(testServer.URL))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		book := client.NewOrderBook(OrderBookInput{Pair: PairETCJPY})
+		var apiErr *APIError
+		if err := book.Sync(context.Background()); !errors.As(err, &apiErr) {
+			t.Errorf("error is not APIError: %v", err)
+		}
+		if !book.Stale() {
+			t.Error("OrderBook must be stale")
+		}
+	})
+
+	t.Run("Run seeds the order book when the stream connects and applies the differences", func(t *testing.T) {
+		var requests atomic.Int32
+		restServer := newOrderBooksServer(t, testOrderBookSnapshot(), &requests)
+		defer restServer.Close()
+
+		wsServer := newWebSocketServer(t, func(conn *websocket.Conn) {
+			if diff := cmp.Diff("etc_jpy-orderbook", readSubscribe(t, conn)); diff != "" {
+				printDiff(t, diff)
+			}
+			// The difference is newer than the snapshot, so it's applied.
+			lastUpdateAt := time.Now().Add(time.Minute).Unix()
+			message := fmt.Sprintf(`["etc_jpy",{"bids":[["3001","2"]],"asks":[],"last_update_at":"%d"}]`, lastUpdateAt)
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(message)); err != nil {
+				t.Error(err)
+			}
+			conn.ReadMessage() //nolint: errcheck // wait for the client to close
+		})
+		defer wsServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(restServer.URL),
+			WithPublicWebSocketURL(webSocketURL(wsServer)),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		book := client.NewOrderBook(OrderBookInput{
+			Pair: PairETCJPY,
+			OnError: func(err error) {
+				t.Error(err)
+			},
+		})
+		var events []BestPriceEvent
+		book.Subscribe(func(e BestPriceEvent) {
+			events = append(events, e)
+			if len(events) == 2 {
+				cancel()
+			}
+		})
+
+		if err := book.Run(ctx); !errors.Is(err, context.Canceled) {
+			t.Fatalf("error is not context.Canceled: %v", err)
+		}
+
+		if diff := cmp.Diff(int32(1), requests.Load()); diff != "" {
+			printDiff(t, diff)
+		}
+		bid, _ := book.BestBid()
+		if diff := cmp.Diff(PriceLevel{Price: MustParseDecimal("3001"), Amount: MustParseDecimal("2")}, bid); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+}
@@ -0,0 +1,175 @@
+package coincheck
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NonceSource generates the ACCESS-NONCE value for signed requests.
+// Coincheck rejects a request whose nonce is not greater than the previous one
+// for the same API key, so the implementation must return a strictly increasing value
+// and must be safe for concurrent use.
+type NonceSource interface {
+	// Nonce returns the next nonce.
+	Nonce() (int64, error)
+}
+
+// nextNonce returns the next nonce. It's based on the current time in nanoseconds,
+// but it's always greater than last even if the clock goes backwards.
+func nextNonce(last int64) int64 {
+	now := time.Now().UnixNano()
+	if now <= last {
+		return last + 1
+	}
+	return now
+}
+
+// MonotonicNonceSource is the default NonceSource.
+// It returns a strictly increasing nonce based on the current time in nanoseconds.
+// If you use the same API key with several clients in one process, share one MonotonicNonceSource
+// between them with WithNonceSource.
+type MonotonicNonceSource struct {
+	// mu protects last.
+	mu sync.Mutex
+	// last is the last nonce.
+	last int64
+}
+
+// NewMonotonicNonceSource returns a new MonotonicNonceSource.
+func NewMonotonicNonceSource() *MonotonicNonceSource {
+	return &MonotonicNonceSource{}
+}
+
+// Nonce returns the next nonce.
+func (m *MonotonicNonceSource) Nonce() (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.last = nextNonce(m.last)
+	return m.last, nil
+}
+
+const (
+	// fileNonceLockTimeout is the maximum time to wait for the lock of the nonce file.
+	// It must be longer than fileNonceStaleLock, so a lock file left by a crashed process
+	// is broken while waiting instead of failing every Nonce call until it becomes stale.
+	fileNonceLockTimeout = 5 * time.Second
+	// fileNonceStaleLock is the age of the lock file that is regarded as left by a crashed process.
+	// The lock is held only while reading and writing a few bytes, so a live holder never keeps it this long.
+	fileNonceStaleLock = 2 * time.Second
+	// fileNonceLockRetryInterval is the interval to retry to get the lock of the nonce file.
+	fileNonceLockRetryInterval = time.Millisecond
+)
+
+// FileNonceSource is a NonceSource that persists the last nonce to a file.
+// The nonce never goes backwards across restarts, and several processes sharing one API key
+// can use the same file. The file is guarded by a lock file (path + ".lock").
+type FileNonceSource struct {
+	// path is the path of the file that stores the last nonce.
+	path string
+	// mu serializes access to the file within the process.
+	mu sync.Mutex
+}
+
+// NewFileNonceSource returns a new FileNonceSource that stores the last nonce to path.
+// If the file does not exist, it is created when the first nonce is generated.
+func NewFileNonceSource(path string) (*FileNonceSource, error) {
+	if path == "" {
+		return nil, fmt.Errorf("%w: path is empty", ErrNonceSource)
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNonceSource, err.Error())
+	}
+	return &FileNonceSource{path: abs}, nil
+}
+
+// Nonce returns the next nonce and stores it to the file.
+func (f *FileNonceSource) Nonce() (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	unlock, err := f.lock()
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	last, err := f.read()
+	if err != nil {
+		return 0, err
+	}
+
+	nonce := nextNonce(last)
+	if err := f.write(nonce); err != nil {
+		return 0, err
+	}
+	return nonce, nil
+}
+
+// lock gets the lock of the nonce file and returns the function to release it.
+func (f *FileNonceSource) lock() (func(), error) {
+	lockPath := f.path + ".lock"
+	deadline := time.Now().Add(fileNonceLockTimeout)
+	for {
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			if err := file.Close(); err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrNonceSource, err.Error())
+			}
+			return func() { os.Remove(lockPath) }, nil //nolint: errcheck // ignore error
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("%w: %s", ErrNonceSource, err.Error())
+		}
+
+		if info, err := os.Stat(lockPath); err == nil && time.Since(info.ModTime()) > fileNonceStaleLock {
+			os.Remove(lockPath) //nolint: errcheck // the other process may remove it first
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%w: timed out waiting for %s", ErrNonceSource, lockPath)
+		}
+		time.Sleep(fileNonceLockRetryInterval)
+	}
+}
+
+// read reads the last nonce from the file. If the file does not exist, it returns 0.
+func (f *FileNonceSource) read() (int64, error) {
+	b, err := os.ReadFile(f.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("%w: %s", ErrNonceSource, err.Error())
+	}
+
+	s := strings.TrimSpace(string(b))
+	if s == "" {
+		return 0, nil
+	}
+	last, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrNonceSource, err.Error())
+	}
+	return last, nil
+}
+
+// write writes the nonce to the file. It writes a temporary file and renames it,
+// so the file never contains a partially written value.
+func (f *FileNonceSource) write(nonce int64) error {
+	tmp := f.path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strconv.FormatInt(nonce, 10)), 0o600); err != nil {
+		return fmt.Errorf("%w: %s", ErrNonceSource, err.Error())
+	}
+	if err := os.Rename(tmp, f.path); err != nil {
+		return fmt.Errorf("%w: %s", ErrNonceSource, err.Error())
+	}
+	return nil
+}
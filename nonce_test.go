@@ -0,0 +1,257 @@
+package coincheck
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMonotonicNonceSource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Nonce returns unique and strictly increasing values in concurrent use", func(t *testing.T) {
+		t.Parallel()
+
+		const goroutines = 16
+		const perGoroutine = 1000
+
+		source := NewMonotonicNonceSource()
+		results := make([][]int64, goroutines)
+
+		var wg sync.WaitGroup
+		for i := 0; i < goroutines; i++ {
+			i := i
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < perGoroutine; j++ {
+					nonce, err := source.Nonce()
+					if err != nil {
+						t.Error(err)
+						return
+					}
+					results[i] = append(results[i], nonce)
+				}
+			}()
+		}
+		wg.Wait()
+
+		seen := make(map[int64]struct{}, goroutines*perGoroutine)
+		for _, nonces := range results {
+			for j, nonce := range nonces {
+				if j > 0 && nonce <= nonces[j-1] {
+					t.Fatalf("nonce did not increase: %d -> %d", nonces[j-1], nonce)
+				}
+				if _, ok := seen[nonce]; ok {
+					t.Fatalf("nonce %d is duplicated", nonce)
+				}
+				seen[nonce] = struct{}{}
+			}
+		}
+	})
+}
+
+func TestFileNonceSource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Nonce never goes backwards across instances", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "nonce")
+		future := int64(1) << 62
+		if err := os.WriteFile(path, []byte(strconv.FormatInt(future, 10)), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		first, err := NewFileNonceSource(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := first.Nonce()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != future+1 {
+			t.Errorf("got %d, want %d", got, future+1)
+		}
+
+		// Emulate a restart.
+		second, err := NewFileNonceSource(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err = second.Nonce()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != future+2 {
+			t.Errorf("got %d, want %d", got, future+2)
+		}
+	})
+
+	t.Run("Nonce returns unique values when several sources share one file", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "nonce")
+		sources := make([]*FileNonceSource, 4)
+		for i := range sources {
+			s, err := NewFileNonceSource(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sources[i] = s
+		}
+
+		var (
+			mu   sync.Mutex
+			seen = map[int64]struct{}{}
+			wg   sync.WaitGroup
+		)
+		for _, s := range sources {
+			s := s
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 50; j++ {
+					nonce, err := s.Nonce()
+					if err != nil {
+						t.Error(err)
+						return
+					}
+					mu.Lock()
+					if _, ok := seen[nonce]; ok {
+						t.Errorf("nonce %d is duplicated", nonce)
+					}
+					seen[nonce] = struct{}{}
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+	})
+
+	t.Run("Nonce returns ErrNonceSource if the file is broken", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "nonce")
+		if err := os.WriteFile(path, []byte("not a number"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		source, err := NewFileNonceSource(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := source.Nonce(); !errors.Is(err, ErrNonceSource) {
+			t.Errorf("error is not ErrNonceSource: %v", err)
+		}
+	})
+
+	t.Run("Nonce breaks a stale lock file left by a crashed process", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "nonce")
+		if err := os.WriteFile(path+".lock", nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		old := time.Now().Add(-time.Hour)
+		if err := os.Chtimes(path+".lock", old, old); err != nil {
+			t.Fatal(err)
+		}
+		source, err := NewFileNonceSource(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := source.Nonce(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("Nonce waits until a lock file left by a crashed process becomes stale", func(t *testing.T) {
+		t.Parallel()
+
+		// The lock file is fresh, as if the holder crashed just now.
+		path := filepath.Join(t.TempDir(), "nonce")
+		if err := os.WriteFile(path+".lock", nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		source, err := NewFileNonceSource(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := source.Nonce(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := os.Stat(path + ".lock"); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("lock file is not released: %v", err)
+		}
+	})
+
+	t.Run("NewFileNonceSource returns ErrNonceSource if the path is empty", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := NewFileNonceSource(""); !errors.Is(err, ErrNonceSource) {
+			t.Errorf("error is not ErrNonceSource: %v", err)
+		}
+	})
+}
+
+// stubNonceSource is a NonceSource that returns a fixed nonce or error.
+type stubNonceSource struct {
+	nonce int64
+	err   error
+}
+
+func (s *stubNonceSource) Nonce() (int64, error) {
+	return s.nonce, s.err
+}
+
+func TestClient_nonceSource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Client sends the nonce generated by the NonceSource", func(t *testing.T) {
+		t.Parallel()
+
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if got := r.Header.Get("ACCESS-NONCE"); got != "1234567890" {
+				t.Errorf("ACCESS-NONCE: got %v, want %v", got, "1234567890")
+			}
+			w.Write([]byte(`{"success":true}`)) //nolint: errcheck // ignore error
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+			WithNonceSource(&stubNonceSource{nonce: 1234567890}),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := client.GetAccountsBalance(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("Client returns ErrNonceSource if the NonceSource fails", func(t *testing.T) {
+		t.Parallel()
+
+		client, err := NewClient(
+			WithBaseURL("https://example.com"),
+			WithCredentials("api_key", "api_secret"),
+			WithNonceSource(&stubNonceSource{err: errors.New("broken")}),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := client.GetAccountsBalance(context.Background()); !errors.Is(err, ErrNonceSource) {
+			t.Errorf("error is not ErrNonceSource: %v", err)
+		}
+	})
+}
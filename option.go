@@ -33,6 +33,30 @@ func WithBaseURL(u string) Option {
 	}
 }
 
+// WithPublicWebSocketURL sets the URL of the public WebSocket API used by PublicStream.
+func WithPublicWebSocketURL(u string) Option {
+	return func(c *Client) error {
+		wsURL, err := url.Parse(u)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidWebSocketURL, err.Error())
+		}
+		c.publicWebSocketURL = wsURL
+		return nil
+	}
+}
+
+// WithPrivateWebSocketURL sets the URL of the private WebSocket API used by PrivateStream.
+func WithPrivateWebSocketURL(u string) Option {
+	return func(c *Client) error {
+		wsURL, err := url.Parse(u)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidWebSocketURL, err.Error())
+		}
+		c.privateWebSocketURL = wsURL
+		return nil
+	}
+}
+
 // WithCredentials sets the credentials to be used to authenticate with the Coincheck API.
 func WithCredentials(key, secret string) Option {
 	return func(c *Client) error {
@@ -43,3 +67,39 @@ func WithCredentials(key, secret string) Option {
 		return nil
 	}
 }
+
+// WithNonceSource sets the NonceSource that generates the ACCESS-NONCE value for signed requests.
+// By default, the client uses its own MonotonicNonceSource. If several clients or processes share
+// one API key, share one NonceSource between them (e.g. FileNonceSource).
+func WithNonceSource(source NonceSource) Option {
+	return func(c *Client) error {
+		if source == nil {
+			return ErrNilNonceSource
+		}
+		c.nonceSource = source
+		return nil
+	}
+}
+
+// WithRawEnvelope disables the detection of "success": false responses.
+// By default, the client converts a response with HTTP 200 and "success": false into APIError.
+// If you set this option, such responses are decoded as they are, and you have to check
+// the Success field of the response by yourself.
+func WithRawEnvelope() Option {
+	return func(c *Client) error {
+		c.rawEnvelope = true
+		return nil
+	}
+}
+
+// WithSendMoneyConfirmer sets the SendMoneyConfirmer that approves each crypto transfer of SendMoney.
+// SendMoney always fails with ErrSendMoneyNotConfirmed unless the client has a SendMoneyConfirmer.
+func WithSendMoneyConfirmer(confirmer SendMoneyConfirmer) Option {
+	return func(c *Client) error {
+		if confirmer == nil {
+			return ErrNilSendMoneyConfirmer
+		}
+		c.sendMoneyConfirmer = confirmer
+		return nil
+	}
+}
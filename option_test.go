@@ -54,4 +54,91 @@ func TestOptions(t *testing.T) {
 			t.Errorf("error is not ErrInvalidBaseURL: %v", err)
 		}
 	})
+
+	t.Run("WithPublicWebSocketURL sets the URL of the public WebSocket API", func(t *testing.T) {
+		t.Parallel()
+
+		wsURL := "ws://localhost:8080/"
+		c, err := NewClient(WithPublicWebSocketURL(wsURL))
+		if err != nil {
+			t.Fatalf("NewClient returned unexpected error: %v", err)
+		}
+
+		if diff := cmp.Diff(c.publicWebSocketURL.String(), wsURL); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("WithPublicWebSocketURL returns an error if the URL is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := NewClient(WithPublicWebSocketURL(":")); !errors.Is(err, ErrInvalidWebSocketURL) {
+			t.Errorf("error is not ErrInvalidWebSocketURL: %v", err)
+		}
+	})
+
+	t.Run("WithPrivateWebSocketURL sets the URL of the private WebSocket API", func(t *testing.T) {
+		t.Parallel()
+
+		wsURL := "ws://localhost:8080/"
+		c, err := NewClient(WithPrivateWebSocketURL(wsURL))
+		if err != nil {
+			t.Fatalf("NewClient returned unexpected error: %v", err)
+		}
+
+		if diff := cmp.Diff(c.privateWebSocketURL.String(), wsURL); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("WithPrivateWebSocketURL returns an error if the URL is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := NewClient(WithPrivateWebSocketURL(":")); !errors.Is(err, ErrInvalidWebSocketURL) {
+			t.Errorf("error is not ErrInvalidWebSocketURL: %v", err)
+		}
+	})
+
+	t.Run("WithNonceSource sets the nonce source for signed requests", func(t *testing.T) {
+		t.Parallel()
+
+		source := NewMonotonicNonceSource()
+		c, err := NewClient(WithNonceSource(source))
+		if err != nil {
+			t.Fatalf("NewClient returned unexpected error: %v", err)
+		}
+
+		if c.nonceSource != source {
+			t.Errorf("nonce source is not set")
+		}
+	})
+
+	t.Run("WithRawEnvelope disables the detection of success false responses", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewClient(WithRawEnvelope())
+		if err != nil {
+			t.Fatalf("NewClient returned unexpected error: %v", err)
+		}
+
+		if !c.rawEnvelope {
+			t.Errorf("rawEnvelope is not set")
+		}
+	})
+
+	t.Run("WithNonceSource returns an error if the nonce source is nil", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := NewClient(WithNonceSource(nil)); !errors.Is(err, ErrNilNonceSource) {
+			t.Errorf("error is not ErrNilNonceSource: %v", err)
+		}
+	})
+
+	t.Run("WithSendMoneyConfirmer returns an error if the confirmer is nil", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := NewClient(WithSendMoneyConfirmer(nil)); !errors.Is(err, ErrNilSendMoneyConfirmer) {
+			t.Errorf("error is not ErrNilSendMoneyConfirmer: %v", err)
+		}
+	})
 }
@@ -18,33 +18,52 @@ const (
 	OrderTypeBuy OrderType = "buy"
 	// OrderTypeSell is the order type of sell.
 	OrderTypeSell OrderType = "sell"
+	// OrderTypeMarketBuy is the order type of market buy. It's only used when creating an order.
+	OrderTypeMarketBuy OrderType = "market_buy"
+	// OrderTypeMarketSell is the order type of market sell. It's only used when creating an order.
+	OrderTypeMarketSell OrderType = "market_sell"
 )
 
 // SellOrderStatus represents the sell order status.
-// It has the only two elements.
-// e.g.  [ "27330", "1.25" ]
-type SellOrderStatus []string
+//
+// Deprecated: Use PriceLevel.
+type SellOrderStatus = PriceLevel
 
 // BuyOrderStatus represents the buy order status.
-// It has the only two elements.
-// e.g.  [ "12100", "0.12" ]
-type BuyOrderStatus []string
+//
+// Deprecated: Use PriceLevel.
+type BuyOrderStatus = PriceLevel
 
 // GetOrderBooksResponse represents the structure of the API response.
+// Asks are sorted by price in ascending order, and Bids are sorted by price in descending order.
 type GetOrderBooksResponse struct {
 	// Asks is the sell order status.
-	Asks []SellOrderStatus `json:"asks"`
+	Asks []PriceLevel `json:"asks"`
 	// Bids is the buy order status.
-	Bids []BuyOrderStatus `json:"bids"`
+	Bids []PriceLevel `json:"bids"`
+}
+
+// GetOrderBooksInput represents the input parameter for GetOrderBooks.
+type GetOrderBooksInput struct {
+	// Pair is the pair of the currency. e.g. btc_jpy.
+	// If it's empty, the pair parameter is not sent and the Coincheck API returns the btc_jpy order book.
+	Pair Pair
 }
 
 // GetOrderBooks fetch order book information.
 // API: GET /api/order_books
 // Visibility: Public
-func (c *Client) GetOrderBooks(ctx context.Context) (*GetOrderBooksResponse, error) {
+// If pair is not specified, you can get the information of btc_jpy.
+func (c *Client) GetOrderBooks(ctx context.Context, input GetOrderBooksInput) (*GetOrderBooksResponse, error) {
+	var queryParam map[string]string
+	if input.Pair != "" {
+		queryParam = map[string]string{"pair": input.Pair.String()}
+	}
+
 	req, err := c.createRequest(ctx, createRequestInput{
-		method: http.MethodGet,
-		path:   "/api/order_books",
+		method:     http.MethodGet,
+		path:       "/api/order_books",
+		queryParam: queryParam,
 	})
 	if err != nil {
 		return nil, err
@@ -56,3 +75,77 @@ func (c *Client) GetOrderBooks(ctx context.Context) (*GetOrderBooksResponse, err
 	}
 	return &output, nil
 }
+
+// defaultMultiOrderBookParallelism is the default number of order books fetched at the same time.
+const defaultMultiOrderBookParallelism = 4
+
+// MultiOrderBookInput represents the input parameter for the MultiOrderBook method.
+type MultiOrderBookInput struct {
+	// Pairs is the pairs of the currency. The duplicated pairs are fetched only once.
+	Pairs []Pair
+	// Parallelism is the maximum number of order books fetched at the same time.
+	// If it's 0 or less, 4 is used. Be careful about the rate limit of the Coincheck API.
+	Parallelism int
+}
+
+// OrderBookResult represents the result of fetching one order book in MultiOrderBook.
+type OrderBookResult struct {
+	// Pair is the pair of the currency.
+	Pair Pair
+	// OrderBook is the order book of the pair. It's nil if Err is not nil.
+	OrderBook *GetOrderBooksResponse
+	// Err is the error returned by GetOrderBooks. It's nil if the order book was fetched.
+	Err error
+}
+
+// MultiOrderBookResponse represents the output from the MultiOrderBook method.
+type MultiOrderBookResponse struct {
+	// Results is the result of each pair. The order is the same as MultiOrderBookInput.Pairs.
+	Results []OrderBookResult
+}
+
+// OrderBook returns the order book of the pair. It returns false if the pair was not fetched.
+func (r *MultiOrderBookResponse) OrderBook(pair Pair) (*GetOrderBooksResponse, bool) {
+	for _, result := range r.Results {
+		if result.Pair == pair && result.Err == nil {
+			return result.OrderBook, true
+		}
+	}
+	return nil, false
+}
+
+// Failed returns the results of the pairs that could not be fetched.
+func (r *MultiOrderBookResponse) Failed() []OrderBookResult {
+	var failed []OrderBookResult
+	for _, result := range r.Results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// MultiOrderBook fetches the order books of several pairs concurrently.
+// Visibility: Public
+// It calls GetOrderBooks for each pair. The error of each pair is reported in MultiOrderBookResponse.Results.
+func (c *Client) MultiOrderBook(ctx context.Context, input MultiOrderBookInput) *MultiOrderBookResponse {
+	results := make([]OrderBookResult, 0, len(input.Pairs))
+	seen := make(map[Pair]struct{}, len(input.Pairs))
+	for _, pair := range input.Pairs {
+		if _, ok := seen[pair]; ok {
+			continue
+		}
+		seen[pair] = struct{}{}
+		results = append(results, OrderBookResult{Pair: pair})
+	}
+
+	parallelism := input.Parallelism
+	if parallelism <= 0 {
+		parallelism = defaultMultiOrderBookParallelism
+	}
+	forEachLimited(len(results), parallelism, func(i int) {
+		results[i].OrderBook, results[i].Err = c.GetOrderBooks(ctx, GetOrderBooksInput{Pair: results[i].Pair})
+	})
+
+	return &MultiOrderBookResponse{Results: results}
+}
@@ -0,0 +1,284 @@
+package coincheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// PriceLevel is a price level of the order book.
+// It's encoded as a two-element JSON array of the price and the amount. e.g. ["27330", "1.25"]
+type PriceLevel struct {
+	// Price is the order rate.
+	Price Decimal
+	// Amount is the total order amount at the price.
+	Amount Decimal
+}
+
+// Notional returns Price * Amount.
+func (p PriceLevel) Notional() Decimal {
+	return p.Price.Mul(p.Amount)
+}
+
+// MarshalJSON encodes p as a two-element JSON array. e.g. ["27330","1.25"]
+func (p PriceLevel) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]Decimal{p.Price, p.Amount})
+}
+
+// UnmarshalJSON decodes p from a two-element JSON array. e.g. ["27330","1.25"]
+func (p *PriceLevel) UnmarshalJSON(b []byte) error {
+	var level []Decimal
+	if err := json.Unmarshal(b, &level); err != nil {
+		return err
+	}
+	if len(level) != 2 {
+		return fmt.Errorf("coincheck: price level must have a price and an amount: %s", string(b))
+	}
+	p.Price, p.Amount = level[0], level[1]
+	return nil
+}
+
+// estimatePrecision is the number of digits after the decimal point of the values
+// that can not be calculated exactly (e.g. the average price).
+const estimatePrecision int32 = 8
+
+// sortedAsks returns the asks with a positive amount, sorted by price in ascending order.
+func (r *GetOrderBooksResponse) sortedAsks() []PriceLevel {
+	asks := nonEmptyLevels(r.Asks)
+	sort.SliceStable(asks, func(i, j int) bool {
+		return asks[i].Price.LessThan(asks[j].Price)
+	})
+	return asks
+}
+
+// sortedBids returns the bids with a positive amount, sorted by price in descending order.
+func (r *GetOrderBooksResponse) sortedBids() []PriceLevel {
+	bids := nonEmptyLevels(r.Bids)
+	sort.SliceStable(bids, func(i, j int) bool {
+		return bids[i].Price.GreaterThan(bids[j].Price)
+	})
+	return bids
+}
+
+// nonEmptyLevels returns a copy of levels without the levels whose amount is 0.
+func nonEmptyLevels(levels []PriceLevel) []PriceLevel {
+	result := make([]PriceLevel, 0, len(levels))
+	for _, l := range levels {
+		if l.Amount.Sign() > 0 {
+			result = append(result, l)
+		}
+	}
+	return result
+}
+
+// levelsToTake returns the price levels that an order of the side takes, best price first.
+// A buy order takes the asks, and a sell order takes the bids.
+func (r *GetOrderBooksResponse) levelsToTake(side OrderType) ([]PriceLevel, error) {
+	switch side {
+	case OrderTypeBuy:
+		return r.sortedAsks(), nil
+	case OrderTypeSell:
+		return r.sortedBids(), nil
+	default:
+		return nil, fmt.Errorf("%w: side must be buy or sell: %q", ErrInvalidOrder, side)
+	}
+}
+
+// BestBid returns the highest bid. It returns false if there is no bid.
+func (r *GetOrderBooksResponse) BestBid() (PriceLevel, bool) {
+	bids := r.sortedBids()
+	if len(bids) == 0 {
+		return PriceLevel{}, false
+	}
+	return bids[0], true
+}
+
+// BestAsk returns the lowest ask. It returns false if there is no ask.
+func (r *GetOrderBooksResponse) BestAsk() (PriceLevel, bool) {
+	asks := r.sortedAsks()
+	if len(asks) == 0 {
+		return PriceLevel{}, false
+	}
+	return asks[0], true
+}
+
+// Spread returns the best ask price minus the best bid price.
+// It returns false if either side of the order book is empty.
+func (r *GetOrderBooksResponse) Spread() (Decimal, bool) {
+	bid, okBid := r.BestBid()
+	ask, okAsk := r.BestAsk()
+	if !okBid || !okAsk {
+		return Decimal{}, false
+	}
+	return ask.Price.Sub(bid.Price), true
+}
+
+// MidPrice returns the average of the best bid price and the best ask price.
+// It returns false if either side of the order book is empty.
+func (r *GetOrderBooksResponse) MidPrice() (Decimal, bool) {
+	bid, okBid := r.BestBid()
+	ask, okAsk := r.BestAsk()
+	if !okBid || !okAsk {
+		return Decimal{}, false
+	}
+	return bid.Price.Add(ask.Price).Mul(NewDecimal(5, 1)), true
+}
+
+// OrderBookDepth is the total amount of the orders on each side of the order book.
+type OrderBookDepth struct {
+	// Bids is the total amount of the bids.
+	Bids Decimal
+	// Asks is the total amount of the asks.
+	Asks Decimal
+}
+
+// DepthWithin returns the total amount of the orders whose price is within percent% of the mid price.
+// e.g. DepthWithin(MustParseDecimal("1")) sums the bids >= mid * 0.99 and the asks <= mid * 1.01.
+// It returns false if either side of the order book is empty.
+func (r *GetOrderBooksResponse) DepthWithin(percent Decimal) (OrderBookDepth, bool) {
+	mid, ok := r.MidPrice()
+	if !ok {
+		return OrderBookDepth{}, false
+	}
+	hundred := NewDecimal(100, 0)
+	ratio := NewDecimal(1, 2) // 1/100
+	lower := mid.Mul(hundred.Sub(percent)).Mul(ratio)
+	upper := mid.Mul(hundred.Add(percent)).Mul(ratio)
+
+	var depth OrderBookDepth
+	for _, bid := range r.sortedBids() {
+		if bid.Price.LessThan(lower) {
+			break
+		}
+		depth.Bids = depth.Bids.Add(bid.Amount)
+	}
+	for _, ask := range r.sortedAsks() {
+		if ask.Price.GreaterThan(upper) {
+			break
+		}
+		depth.Asks = depth.Asks.Add(ask.Amount)
+	}
+	return depth, true
+}
+
+// DepthPoint is a point of the cumulative depth curve.
+type DepthPoint struct {
+	// Price is the price of the level.
+	Price Decimal
+	// Amount is the total amount from the best price to Price.
+	Amount Decimal
+	// Notional is the total Price * Amount from the best price to Price.
+	Notional Decimal
+}
+
+// CumulativeBids returns the cumulative depth curve of the bids, from the highest price.
+func (r *GetOrderBooksResponse) CumulativeBids() []DepthPoint {
+	return cumulativeDepth(r.sortedBids())
+}
+
+// CumulativeAsks returns the cumulative depth curve of the asks, from the lowest price.
+func (r *GetOrderBooksResponse) CumulativeAsks() []DepthPoint {
+	return cumulativeDepth(r.sortedAsks())
+}
+
+// cumulativeDepth returns the cumulative depth curve of the sorted levels.
+func cumulativeDepth(levels []PriceLevel) []DepthPoint {
+	points := make([]DepthPoint, 0, len(levels))
+	var amount, notional Decimal
+	for _, l := range levels {
+		amount = amount.Add(l.Amount)
+		notional = notional.Add(l.Notional())
+		points = append(points, DepthPoint{Price: l.Price, Amount: amount, Notional: notional})
+	}
+	return points
+}
+
+// FillEstimate is the estimated result of an order that takes the order book.
+type FillEstimate struct {
+	// Side is the side of the order.
+	Side OrderType
+	// Amount is the amount to fill.
+	Amount Decimal
+	// Cost is the total Price * Amount of the filled levels.
+	Cost Decimal
+	// AveragePrice is the volume weighted average price (VWAP), Cost / Amount.
+	// It's rounded to 8 digits after the decimal point.
+	AveragePrice Decimal
+	// BestPrice is the price of the first level.
+	BestPrice Decimal
+	// WorstPrice is the price of the last level that the order reaches.
+	WorstPrice Decimal
+	// Slippage is the difference between the average price and the best price, relative to the best price.
+	// e.g. 0.001 means 0.1%.
+	// It's positive when the average price is worse than the best price.
+	// It's rounded to 8 digits after the decimal point.
+	Slippage Decimal
+}
+
+// EstimateFill estimates the result of an order of side that fills amount by taking the order book.
+// side must be OrderTypeBuy or OrderTypeSell, and amount is the base currency amount (e.g. BTC).
+// A buy order takes the asks, and a sell order takes the bids.
+// It returns ErrInsufficientLiquidity if the order book does not have enough amount,
+// and ErrInvalidOrderBook if the best price is zero or negative.
+func (r *GetOrderBooksResponse) EstimateFill(side OrderType, amount Decimal) (*FillEstimate, error) {
+	levels, err := r.levelsToTake(side)
+	if err != nil {
+		return nil, err
+	}
+	if amount.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: amount must be positive: %s", ErrInvalidOrder, amount)
+	}
+	if len(levels) == 0 {
+		return nil, fmt.Errorf("%w: order book is empty", ErrInsufficientLiquidity)
+	}
+
+	if levels[0].Price.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: best price is not positive: %s", ErrInvalidOrderBook, levels[0].Price)
+	}
+
+	estimate := &FillEstimate{Side: side, Amount: amount, BestPrice: levels[0].Price}
+	remaining := amount
+	for _, l := range levels {
+		if remaining.Sign() <= 0 {
+			break
+		}
+		filled := l.Amount
+		if remaining.LessThan(filled) {
+			filled = remaining
+		}
+		estimate.Cost = estimate.Cost.Add(l.Price.Mul(filled))
+		estimate.WorstPrice = l.Price
+		remaining = remaining.Sub(filled)
+	}
+	if remaining.Sign() > 0 {
+		return nil, fmt.Errorf("%w: %s of %s can not be filled", ErrInsufficientLiquidity, remaining, amount)
+	}
+
+	estimate.AveragePrice = estimate.Cost.Div(amount, estimatePrecision)
+	diff := estimate.AveragePrice.Sub(estimate.BestPrice)
+	if side == OrderTypeSell {
+		diff = diff.Neg()
+	}
+	estimate.Slippage = diff.Div(estimate.BestPrice, estimatePrecision)
+	return estimate, nil
+}
+
+// VWAP returns the volume weighted average price to fill amount on side.
+// It's the same as EstimateFill(side, amount).AveragePrice.
+func (r *GetOrderBooksResponse) VWAP(side OrderType, amount Decimal) (Decimal, error) {
+	estimate, err := r.EstimateFill(side, amount)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return estimate.AveragePrice, nil
+}
+
+// Slippage returns the estimated slippage to fill amount on side.
+// It's the same as EstimateFill(side, amount).Slippage.
+func (r *GetOrderBooksResponse) Slippage(side OrderType, amount Decimal) (Decimal, error) {
+	estimate, err := r.EstimateFill(side, amount)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return estimate.Slippage, nil
+}
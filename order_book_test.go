@@ -0,0 +1,231 @@
+package coincheck
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// testOrderBook returns an order book for the analytics tests. The levels are not sorted on purpose.
+func testOrderBook() *GetOrderBooksResponse {
+	return &GetOrderBooksResponse{
+		Asks: []PriceLevel{
+			{Price: MustParseDecimal("101"), Amount: MustParseDecimal("2")},
+			{Price: MustParseDecimal("100"), Amount: MustParseDecimal("1")},
+			{Price: MustParseDecimal("100.5"), Amount: MustParseDecimal("0")},
+			{Price: MustParseDecimal("103"), Amount: MustParseDecimal("1")},
+		},
+		Bids: []PriceLevel{
+			{Price: MustParseDecimal("99"), Amount: MustParseDecimal("1")},
+			{Price: MustParseDecimal("95"), Amount: MustParseDecimal("5")},
+			{Price: MustParseDecimal("98"), Amount: MustParseDecimal("2")},
+		},
+	}
+}
+
+func TestPriceLevel(t *testing.T) {
+	t.Parallel()
+
+	t.Run("PriceLevel is decoded from a two-element array", func(t *testing.T) {
+		t.Parallel()
+
+		var got GetOrderBooksResponse
+		in := `{"asks":[["27330","2.0"]],"bids":[[27320.5,"0.1"]]}`
+		if err := json.Unmarshal([]byte(in), &got); err != nil {
+			t.Fatal(err)
+		}
+		want := GetOrderBooksResponse{
+			Asks: []PriceLevel{{Price: MustParseDecimal("27330"), Amount: MustParseDecimal("2")}},
+			Bids: []PriceLevel{{Price: MustParseDecimal("27320.5"), Amount: MustParseDecimal("0.1")}},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			printDiff(t, diff)
+		}
+
+		b, err := json.Marshal(got.Asks[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(`["27330","2.0"]`, string(b)); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("PriceLevel returns an error if the array does not have two elements", func(t *testing.T) {
+		t.Parallel()
+
+		var got PriceLevel
+		if err := json.Unmarshal([]byte(`["27330"]`), &got); err == nil {
+			t.Error("want error, but got nil")
+		}
+	})
+}
+
+func TestGetOrderBooksResponse_Analytics(t *testing.T) {
+	t.Parallel()
+
+	t.Run("BestBid, BestAsk, Spread and MidPrice", func(t *testing.T) {
+		t.Parallel()
+
+		book := testOrderBook()
+		bid, ok := book.BestBid()
+		if !ok {
+			t.Fatal("BestBid must exist")
+		}
+		if diff := cmp.Diff(PriceLevel{Price: MustParseDecimal("99"), Amount: MustParseDecimal("1")}, bid); diff != "" {
+			printDiff(t, diff)
+		}
+		ask, ok := book.BestAsk()
+		if !ok {
+			t.Fatal("BestAsk must exist")
+		}
+		if diff := cmp.Diff(PriceLevel{Price: MustParseDecimal("100"), Amount: MustParseDecimal("1")}, ask); diff != "" {
+			printDiff(t, diff)
+		}
+		spread, _ := book.Spread()
+		if diff := cmp.Diff("1", spread.String()); diff != "" {
+			printDiff(t, diff)
+		}
+		mid, _ := book.MidPrice()
+		if diff := cmp.Diff("99.5", mid.String()); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("Analytics return false for an empty side", func(t *testing.T) {
+		t.Parallel()
+
+		book := &GetOrderBooksResponse{Asks: testOrderBook().Asks}
+		if _, ok := book.BestBid(); ok {
+			t.Error("BestBid must not exist")
+		}
+		if _, ok := book.Spread(); ok {
+			t.Error("Spread must not exist")
+		}
+		if _, ok := book.MidPrice(); ok {
+			t.Error("MidPrice must not exist")
+		}
+		if _, ok := book.DepthWithin(MustParseDecimal("1")); ok {
+			t.Error("DepthWithin must not exist")
+		}
+	})
+
+	t.Run("DepthWithin sums the levels within percent of the mid price", func(t *testing.T) {
+		t.Parallel()
+
+		// mid = 99.5, lower = 97.51, upper = 101.49
+		got, ok := testOrderBook().DepthWithin(MustParseDecimal("2"))
+		if !ok {
+			t.Fatal("DepthWithin must exist")
+		}
+		want := OrderBookDepth{Bids: MustParseDecimal("3"), Asks: MustParseDecimal("3")}
+		if diff := cmp.Diff(want, got); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("CumulativeAsks and CumulativeBids start from the best price", func(t *testing.T) {
+		t.Parallel()
+
+		book := testOrderBook()
+		wantAsks := []DepthPoint{
+			{Price: MustParseDecimal("100"), Amount: MustParseDecimal("1"), Notional: MustParseDecimal("100")},
+			{Price: MustParseDecimal("101"), Amount: MustParseDecimal("3"), Notional: MustParseDecimal("302")},
+			{Price: MustParseDecimal("103"), Amount: MustParseDecimal("4"), Notional: MustParseDecimal("405")},
+		}
+		if diff := cmp.Diff(wantAsks, book.CumulativeAsks()); diff != "" {
+			printDiff(t, diff)
+		}
+		wantBids := []DepthPoint{
+			{Price: MustParseDecimal("99"), Amount: MustParseDecimal("1"), Notional: MustParseDecimal("99")},
+			{Price: MustParseDecimal("98"), Amount: MustParseDecimal("3"), Notional: MustParseDecimal("295")},
+			{Price: MustParseDecimal("95"), Amount: MustParseDecimal("8"), Notional: MustParseDecimal("770")},
+		}
+		if diff := cmp.Diff(wantBids, book.CumulativeBids()); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("EstimateFill walks the levels from the best price", func(t *testing.T) {
+		t.Parallel()
+
+		book := testOrderBook()
+		got, err := book.EstimateFill(OrderTypeBuy, MustParseDecimal("2"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &FillEstimate{
+			Side:         OrderTypeBuy,
+			Amount:       MustParseDecimal("2"),
+			Cost:         MustParseDecimal("201"),
+			AveragePrice: MustParseDecimal("100.5"),
+			BestPrice:    MustParseDecimal("100"),
+			WorstPrice:   MustParseDecimal("101"),
+			Slippage:     MustParseDecimal("0.005"),
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			printDiff(t, diff)
+		}
+
+		vwap, err := book.VWAP(OrderTypeSell, MustParseDecimal("2"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff("98.50000000", vwap.String()); diff != "" {
+			printDiff(t, diff)
+		}
+		slippage, err := book.Slippage(OrderTypeSell, MustParseDecimal("2"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// (99 - 98.5) / 99
+		if diff := cmp.Diff("0.00505051", slippage.String()); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("EstimateFill returns ErrInsufficientLiquidity if the order book is not deep enough", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := testOrderBook().EstimateFill(OrderTypeBuy, MustParseDecimal("4.1")); !errors.Is(err, ErrInsufficientLiquidity) {
+			t.Errorf("error is not ErrInsufficientLiquidity: %v", err)
+		}
+		if _, err := (&GetOrderBooksResponse{}).VWAP(OrderTypeSell, MustParseDecimal("1")); !errors.Is(err, ErrInsufficientLiquidity) {
+			t.Errorf("error is not ErrInsufficientLiquidity: %v", err)
+		}
+	})
+
+	t.Run("EstimateFill returns ErrInvalidOrder for an invalid side or amount", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := testOrderBook().EstimateFill("", MustParseDecimal("1")); !errors.Is(err, ErrInvalidOrder) {
+			t.Errorf("error is not ErrInvalidOrder: %v", err)
+		}
+		if _, err := testOrderBook().EstimateFill(OrderTypeBuy, Decimal{}); !errors.Is(err, ErrInvalidOrder) {
+			t.Errorf("error is not ErrInvalidOrder: %v", err)
+		}
+	})
+
+	t.Run("EstimateFill rejects market orders, because the market buy amount is in JPY", func(t *testing.T) {
+		t.Parallel()
+
+		for _, side := range []OrderType{OrderTypeMarketBuy, OrderTypeMarketSell} {
+			if _, err := testOrderBook().EstimateFill(side, MustParseDecimal("1")); !errors.Is(err, ErrInvalidOrder) {
+				t.Errorf("%s: error is not ErrInvalidOrder: %v", side, err)
+			}
+		}
+	})
+
+	t.Run("EstimateFill returns ErrInvalidOrderBook if the best price is zero", func(t *testing.T) {
+		t.Parallel()
+
+		book := &GetOrderBooksResponse{
+			Bids: []PriceLevel{{Price: MustParseDecimal("0"), Amount: MustParseDecimal("1")}},
+		}
+		if _, err := book.EstimateFill(OrderTypeSell, MustParseDecimal("1")); !errors.Is(err, ErrInvalidOrderBook) {
+			t.Errorf("error is not ErrInvalidOrderBook: %v", err)
+		}
+	})
+}
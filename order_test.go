@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -23,13 +25,17 @@ func TestClientGetOrderBooksResponse(t *testing.T) {
 				t.Errorf("Endpoint: got %v, want %v", got, wantEndpoint)
 			}
 
+			if r.URL.Query().Has("pair") {
+				t.Errorf("pair must not be sent: %v", r.URL.RawQuery)
+			}
+
 			result := GetOrderBooksResponse{
-				Asks: []SellOrderStatus{
-					{"27330", "2.25"},
-					{"27340", "0.1"},
+				Asks: []PriceLevel{
+					{Price: MustParseDecimal("27330"), Amount: MustParseDecimal("2.25")},
+					{Price: MustParseDecimal("27340"), Amount: MustParseDecimal("0.1")},
 				},
-				Bids: []BuyOrderStatus{
-					{"27320", "0.2"},
+				Bids: []PriceLevel{
+					{Price: MustParseDecimal("27320"), Amount: MustParseDecimal("0.2")},
 				},
 			}
 			if err := json.NewEncoder(w).Encode(result); err != nil {
@@ -43,17 +49,17 @@ func TestClientGetOrderBooksResponse(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		got, err := client.GetOrderBooks(context.Background())
+		got, err := client.GetOrderBooks(context.Background(), GetOrderBooksInput{})
 		if err != nil {
 			t.Fatal(err)
 		}
 		want := &GetOrderBooksResponse{
-			Asks: []SellOrderStatus{
-				{"27330", "2.25"},
-				{"27340", "0.1"},
+			Asks: []PriceLevel{
+				{Price: MustParseDecimal("27330"), Amount: MustParseDecimal("2.25")},
+				{Price: MustParseDecimal("27340"), Amount: MustParseDecimal("0.1")},
 			},
-			Bids: []BuyOrderStatus{
-				{"27320", "0.2"},
+			Bids: []PriceLevel{
+				{Price: MustParseDecimal("27320"), Amount: MustParseDecimal("0.2")},
 			},
 		}
 		if diff := cmp.Diff(want, got); diff != "" {
@@ -72,8 +78,104 @@ func TestClientGetOrderBooksResponse(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		if _, err = client.GetOrderBooks(context.Background()); err == nil {
+		if _, err = client.GetOrderBooks(context.Background(), GetOrderBooksInput{}); err == nil {
 			t.Error("want error, but got nil")
 		}
 	})
+
+	t.Run("GetOrderBooks sends the pair", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			wantPair := PairETCJPY
+			if got := r.URL.Query().Get("pair"); got != wantPair.String() {
+				t.Errorf("pair: got %v, want %v", got, wantPair)
+			}
+
+			result := GetOrderBooksResponse{
+				Asks: []PriceLevel{{Price: MustParseDecimal("3000"), Amount: MustParseDecimal("1")}},
+			}
+			if err := json.NewEncoder(w).Encode(result); err != nil {
+				t.Fatal(err)
+			}
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(WithBaseURL(testServer.URL))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := client.GetOrderBooks(context.Background(), GetOrderBooksInput{Pair: PairETCJPY})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &GetOrderBooksResponse{
+			Asks: []PriceLevel{{Price: MustParseDecimal("3000"), Amount: MustParseDecimal("1")}},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+}
+
+func TestClient_MultiOrderBook(t *testing.T) {
+	t.Run("MultiOrderBook fetches the order book of each pair", func(t *testing.T) {
+		var requests atomic.Int32
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+
+			pair := r.URL.Query().Get("pair")
+			if pair == PairBrilJPY.String() {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			result := GetOrderBooksResponse{
+				Bids: []PriceLevel{{Price: MustParseDecimal(strconv.Itoa(len(pair))), Amount: MustParseDecimal("1")}},
+			}
+			if err := json.NewEncoder(w).Encode(result); err != nil {
+				t.Fatal(err)
+			}
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(WithBaseURL(testServer.URL))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := client.MultiOrderBook(context.Background(), MultiOrderBookInput{
+			Pairs:       []Pair{PairBTCJPY, PairETCJPY, PairMonaJPY, PairBrilJPY, PairETCJPY},
+			Parallelism: 2,
+		})
+
+		if diff := cmp.Diff(int32(4), requests.Load()); diff != "" {
+			printDiff(t, diff)
+		}
+
+		var pairs []Pair
+		for _, result := range got.Results {
+			pairs = append(pairs, result.Pair)
+		}
+		if diff := cmp.Diff([]Pair{PairBTCJPY, PairETCJPY, PairMonaJPY, PairBrilJPY}, pairs); diff != "" {
+			printDiff(t, diff)
+		}
+
+		book, ok := got.OrderBook(PairMonaJPY)
+		if !ok {
+			t.Fatal("mona_jpy order book must be fetched")
+		}
+		want := &GetOrderBooksResponse{
+			Bids: []PriceLevel{{Price: MustParseDecimal("8"), Amount: MustParseDecimal("1")}},
+		}
+		if diff := cmp.Diff(want, book); diff != "" {
+			printDiff(t, diff)
+		}
+
+		failed := got.Failed()
+		if len(failed) != 1 || failed[0].Pair != PairBrilJPY {
+			t.Fatalf("only bril_jpy must fail: %+v", failed)
+		}
+		if _, ok := got.OrderBook(PairBrilJPY); ok {
+			t.Error("bril_jpy order book must not be returned")
+		}
+	})
 }
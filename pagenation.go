@@ -1,5 +1,7 @@
 package coincheck
 
+import "strconv"
+
 // Pagination represents the pagination of coincheck API.
 // It is possible to get by dividing the data.
 type Pagination struct {
@@ -24,3 +26,34 @@ const (
 	// PaginationOrderAsc is the order of the pagination in ascending order.
 	PaginationOrderAsc PaginationOrder = "asc"
 )
+
+// queryParam returns the query parameters of the pagination.
+// The zero value fields are not included, so the Coincheck API uses its default values.
+func (p Pagination) queryParam() map[string]string {
+	queryParam := map[string]string{}
+	if p.Limit > 0 {
+		queryParam["limit"] = strconv.Itoa(p.Limit)
+	}
+	if p.PaginationOrder != "" {
+		queryParam["order"] = string(p.PaginationOrder)
+	}
+	if p.StartingAfter > 0 {
+		queryParam["starting_after"] = strconv.Itoa(p.StartingAfter)
+	}
+	if p.EndingBefore > 0 {
+		queryParam["ending_before"] = strconv.Itoa(p.EndingBefore)
+	}
+	return queryParam
+}
+
+// next returns the pagination of the next page. lastID is the ID of the last data in the current page.
+// In descending order (the default of the Coincheck API), the next page is the data less than lastID.
+// In ascending order, the next page is the data greater than lastID.
+func (p Pagination) next(lastID int) Pagination {
+	if p.PaginationOrder == PaginationOrderAsc {
+		p.StartingAfter = lastID
+	} else {
+		p.EndingBefore = lastID
+	}
+	return p
+}
@@ -0,0 +1,131 @@
+package coincheck
+
+import (
+	"context"
+)
+
+// PageFetcher fetches one page of a list endpoint with the pagination.
+// e.g. a function that calls GetTransactionsPagination and returns its Data.
+type PageFetcher[T any] func(ctx context.Context, pagination Pagination) ([]T, error)
+
+// Cursor is the position of a Paginator. It can be serialized to JSON and passed to
+// PaginatorInput.Cursor, so a long backfill can restart where it stopped.
+type Cursor struct {
+	// Pagination is the pagination of the next page.
+	Pagination Pagination `json:"pagination"`
+	// Fetched is the number of items already fetched.
+	Fetched int `json:"fetched"`
+	// Done is true if there is no more page.
+	Done bool `json:"done"`
+}
+
+// PaginatorInput represents the input parameter for NewPaginator.
+type PaginatorInput struct {
+	// Pagination is the pagination of the first page. Pagination.Limit is the page size.
+	// If Pagination.PaginationOrder is empty, the descending order (the default of the Coincheck API) is assumed.
+	Pagination Pagination
+	// MaxItems is the maximum number of items to be fetched. If it's 0 or less, there is no limit.
+	MaxItems int
+	// Cursor is the cursor to resume from. If it's not nil, Pagination is ignored.
+	Cursor *Cursor
+}
+
+// Paginator walks the pages of a list endpoint with starting_after (asc) or ending_before (desc).
+//
+//	p := coincheck.NewPaginator(fetch, func(tx coincheck.Transaction) int { return tx.ID }, coincheck.PaginatorInput{})
+//	for p.HasNext() {
+//		page, err := p.Next(ctx)
+//		if err != nil {
+//			// ...
+//		}
+//		// ...
+//	}
+type Paginator[T any] struct {
+	// fetch fetches one page.
+	fetch PageFetcher[T]
+	// id returns the ID of the item. It's used to build the pagination of the next page.
+	id func(T) int
+	// maxItems is the maximum number of items to be fetched.
+	maxItems int
+	// cursor is the current position.
+	cursor Cursor
+}
+
+// NewPaginator returns a new Paginator. id returns the ID of the item that is used as
+// starting_after or ending_before of the next page.
+func NewPaginator[T any](fetch PageFetcher[T], id func(T) int, input PaginatorInput) *Paginator[T] {
+	cursor := Cursor{Pagination: input.Pagination}
+	if input.Cursor != nil {
+		cursor = *input.Cursor
+	}
+	return &Paginator[T]{
+		fetch:    fetch,
+		id:       id,
+		maxItems: input.MaxItems,
+		cursor:   cursor,
+	}
+}
+
+// HasNext returns true if there may be more pages.
+func (p *Paginator[T]) HasNext() bool {
+	if p.cursor.Done {
+		return false
+	}
+	return p.maxItems <= 0 || p.cursor.Fetched < p.maxItems
+}
+
+// Next fetches the next page. If there is no more page, it returns an empty slice.
+// The end of the pages is detected by an empty page, so the last call of Next fetches one more page.
+// If ctx is cancelled, it returns the error and the cursor is not advanced, so you can retry.
+func (p *Paginator[T]) Next(ctx context.Context) ([]T, error) {
+	if !p.HasNext() {
+		return nil, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, withPrefixError(err)
+	}
+
+	page, err := p.fetch(ctx, p.cursor.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	// A page shorter than the limit does not mean the last page, because the server may cap the page size.
+	// So the pagination ends only with an empty page.
+	if len(page) == 0 {
+		p.cursor.Done = true
+		return page, nil
+	}
+	if p.maxItems > 0 && p.cursor.Fetched+len(page) >= p.maxItems {
+		page = page[:p.maxItems-p.cursor.Fetched]
+		p.cursor.Done = true
+	}
+
+	p.cursor.Fetched += len(page)
+	next := p.cursor.Pagination.next(p.id(page[len(page)-1]))
+	if next == p.cursor.Pagination {
+		// The page only has the items already fetched (e.g. the server returns overlapping pages),
+		// so the next page would be the same.
+		p.cursor.Done = true
+	}
+	p.cursor.Pagination = next
+	return page, nil
+}
+
+// All fetches all remaining pages and returns the items.
+// If an error occurs, it returns the items fetched so far with the error.
+func (p *Paginator[T]) All(ctx context.Context) ([]T, error) {
+	var all []T
+	for p.HasNext() {
+		page, err := p.Next(ctx)
+		if err != nil {
+			return all, err
+		}
+		all = append(all, page...)
+	}
+	return all, nil
+}
+
+// Cursor returns the current position. You can save it and resume with PaginatorInput.Cursor.
+func (p *Paginator[T]) Cursor() Cursor {
+	return p.cursor
+}
@@ -0,0 +1,36 @@
+//go:build go1.23
+
+package coincheck
+
+import (
+	"context"
+	"iter"
+)
+
+// Seq returns an iterator over the remaining items for range-over-func.
+// If an error occurs, it yields the zero value with the error and stops.
+// The cursor advances by page, so if you stop in the middle of a page, the rest of the page is skipped.
+//
+//	for tx, err := range p.Seq(ctx) {
+//		if err != nil {
+//			// ...
+//		}
+//		// ...
+//	}
+func (p *Paginator[T]) Seq(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for p.HasNext() {
+			page, err := p.Next(ctx)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range page {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
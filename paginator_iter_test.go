@@ -0,0 +1,52 @@
+//go:build go1.23
+
+package coincheck
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPaginator_Seq(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Seq yields all items across pages", func(t *testing.T) {
+		t.Parallel()
+
+		p := NewPaginator(fakeFetcher(5, nil), identity, PaginatorInput{
+			Pagination: Pagination{Limit: 2, PaginationOrder: PaginationOrderAsc},
+		})
+
+		var got []int
+		for id, err := range p.Seq(context.Background()) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, id)
+		}
+		if diff := cmp.Diff([]int{1, 2, 3, 4, 5}, got); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("Seq stops when the loop breaks", func(t *testing.T) {
+		t.Parallel()
+
+		p := NewPaginator(fakeFetcher(5, nil), identity, PaginatorInput{
+			Pagination: Pagination{Limit: 2, PaginationOrder: PaginationOrderAsc},
+		})
+
+		var got []int
+		for id := range p.Seq(context.Background()) {
+			got = append(got, id)
+			if id == 3 {
+				break
+			}
+		}
+		if diff := cmp.Diff([]int{1, 2, 3}, got); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+}
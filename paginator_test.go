@@ -0,0 +1,184 @@
+package coincheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// fakeFetcher returns a PageFetcher that serves IDs from 1 to total
+// in the same way as the Coincheck API, and records the requested paginations.
+func fakeFetcher(total int, requested *[]Pagination) PageFetcher[int] {
+	return func(_ context.Context, p Pagination) ([]int, error) {
+		if requested != nil {
+			*requested = append(*requested, p)
+		}
+		limit := p.Limit
+		if limit == 0 {
+			limit = 25
+		}
+
+		var page []int
+		if p.PaginationOrder == PaginationOrderAsc {
+			for id := p.StartingAfter + 1; id <= total && len(page) < limit; id++ {
+				page = append(page, id)
+			}
+			return page, nil
+		}
+
+		from := total
+		if p.EndingBefore > 0 {
+			from = p.EndingBefore - 1
+		}
+		for id := from; id > p.StartingAfter && len(page) < limit; id-- {
+			page = append(page, id)
+		}
+		return page, nil
+	}
+}
+
+// identity returns the item itself as the ID.
+func identity(i int) int {
+	return i
+}
+
+func TestPaginator(t *testing.T) {
+	t.Parallel()
+
+	t.Run("All walks all pages with ending_before in descending order", func(t *testing.T) {
+		t.Parallel()
+
+		var requested []Pagination
+		p := NewPaginator(fakeFetcher(5, &requested), identity, PaginatorInput{
+			Pagination: Pagination{Limit: 2},
+		})
+
+		got, err := p.All(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]int{5, 4, 3, 2, 1}, got); diff != "" {
+			printDiff(t, diff)
+		}
+
+		wantRequested := []Pagination{
+			{Limit: 2},
+			{Limit: 2, EndingBefore: 4},
+			{Limit: 2, EndingBefore: 2},
+			{Limit: 2, EndingBefore: 1},
+		}
+		if diff := cmp.Diff(wantRequested, requested); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("All walks all pages if the server caps the page size below the limit", func(t *testing.T) {
+		t.Parallel()
+
+		fetch := fakeFetcher(7, nil)
+		capped := func(ctx context.Context, p Pagination) ([]int, error) {
+			if p.Limit > 2 {
+				p.Limit = 2
+			}
+			return fetch(ctx, p)
+		}
+		p := NewPaginator(capped, identity, PaginatorInput{
+			Pagination: Pagination{Limit: 5, PaginationOrder: PaginationOrderAsc},
+		})
+
+		got, err := p.All(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]int{1, 2, 3, 4, 5, 6, 7}, got); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("All stops at MaxItems", func(t *testing.T) {
+		t.Parallel()
+
+		p := NewPaginator(fakeFetcher(100, nil), identity, PaginatorInput{
+			Pagination: Pagination{Limit: 3, PaginationOrder: PaginationOrderAsc},
+			MaxItems:   7,
+		})
+
+		got, err := p.All(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]int{1, 2, 3, 4, 5, 6, 7}, got); diff != "" {
+			printDiff(t, diff)
+		}
+		if p.HasNext() {
+			t.Error("HasNext must be false after MaxItems")
+		}
+	})
+
+	t.Run("Paginator resumes from the serialized cursor", func(t *testing.T) {
+		t.Parallel()
+
+		first := NewPaginator(fakeFetcher(6, nil), identity, PaginatorInput{
+			Pagination: Pagination{Limit: 2, PaginationOrder: PaginationOrderAsc},
+		})
+		page, err := first.Next(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]int{1, 2}, page); diff != "" {
+			printDiff(t, diff)
+		}
+
+		b, err := json.Marshal(first.Cursor())
+		if err != nil {
+			t.Fatal(err)
+		}
+		var cursor Cursor
+		if err := json.Unmarshal(b, &cursor); err != nil {
+			t.Fatal(err)
+		}
+
+		second := NewPaginator(fakeFetcher(6, nil), identity, PaginatorInput{Cursor: &cursor})
+		got, err := second.All(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]int{3, 4, 5, 6}, got); diff != "" {
+			printDiff(t, diff)
+		}
+		if diff := cmp.Diff(6, second.Cursor().Fetched); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("Next returns the error of the fetcher and does not advance the cursor", func(t *testing.T) {
+		t.Parallel()
+
+		errFetch := errors.New("fetch error")
+		p := NewPaginator(func(context.Context, Pagination) ([]int, error) {
+			return nil, errFetch
+		}, identity, PaginatorInput{Pagination: Pagination{Limit: 2}})
+
+		if _, err := p.Next(context.Background()); !errors.Is(err, errFetch) {
+			t.Errorf("error is not errFetch: %v", err)
+		}
+		if diff := cmp.Diff(Cursor{Pagination: Pagination{Limit: 2}}, p.Cursor()); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("Next returns an error if the context is cancelled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		p := NewPaginator(fakeFetcher(5, nil), identity, PaginatorInput{})
+		if _, err := p.Next(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("error is not context.Canceled: %v", err)
+		}
+	})
+}
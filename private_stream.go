@@ -0,0 +1,268 @@
+package coincheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// OrderEventType represents the kind of the change of your order in the order-events channel.
+type OrderEventType string
+
+// String returns the string representation of the OrderEventType.
+func (o OrderEventType) String() string {
+	return string(o)
+}
+
+const (
+	// OrderEventNew means the order was created.
+	OrderEventNew OrderEventType = "NEW"
+	// OrderEventPartiallyFilled means the order was partially filled.
+	OrderEventPartiallyFilled OrderEventType = "PARTIALLY_FILL"
+	// OrderEventFilled means the order was completely filled.
+	OrderEventFilled OrderEventType = "FILL"
+	// OrderEventCanceled means the order was cancelled.
+	OrderEventCanceled OrderEventType = "CANCEL"
+	// OrderEventExpired means the order was expired (e.g. a post_only order that would be a taker).
+	OrderEventExpired OrderEventType = "EXPIRY"
+)
+
+// OrderStatus returns the status of the order after the event. e.g. OrderStatusFilled for FILL.
+// CANCEL and EXPIRY are mapped to OrderStatusCanceled and OrderStatusExpired even if the order
+// was partially filled. Use GetOrder to get the exact status.
+func (o OrderEventType) OrderStatus() OrderStatus {
+	switch o {
+	case OrderEventNew:
+		return OrderStatusNew
+	case OrderEventPartiallyFilled:
+		return OrderStatusPartiallyFilled
+	case OrderEventFilled:
+		return OrderStatusFilled
+	case OrderEventCanceled:
+		return OrderStatusCanceled
+	case OrderEventExpired:
+		return OrderStatusExpired
+	default:
+		return OrderStatus(o)
+	}
+}
+
+// OrderEvent is a change of your order received from the order-events channel.
+type OrderEvent struct {
+	// ID is the order ID. It's the same as CreateOrderResponse.ID and OpenOrder.ID.
+	ID int `json:"id"`
+	// Pair is the pair of the currency.
+	Pair Pair `json:"pair"`
+	// OrderEvent is the kind of the change.
+	OrderEvent OrderEventType `json:"order_event"`
+	// OrderType is the order type.
+	OrderType OrderType `json:"order_type"`
+	// Rate is the order rate. It's zero for market orders.
+	Rate Decimal `json:"rate"`
+	// StopLossRate is the stop loss rate. It's zero if not set.
+	StopLossRate Decimal `json:"stop_loss_rate"`
+	// MakerFeeRate is the fee rate when the order is a maker.
+	MakerFeeRate Decimal `json:"maker_fee_rate"`
+	// TakerFeeRate is the fee rate when the order is a taker.
+	TakerFeeRate Decimal `json:"taker_fee_rate"`
+	// Amount is the order amount.
+	Amount Decimal `json:"amount"`
+	// MarketBuyAmount is the JPY amount of the market buy order.
+	MarketBuyAmount Decimal `json:"market_buy_amount"`
+	// LatestExecutedAmount is the amount executed by this event.
+	LatestExecutedAmount Decimal `json:"latest_executed_amount"`
+	// LatestExecutedMarketBuyAmount is the JPY amount of the market buy order executed by this event.
+	LatestExecutedMarketBuyAmount Decimal `json:"latest_executed_market_buy_amount"`
+	// ExpiredType is the reason of the expiry. It's empty unless OrderEvent is EXPIRY.
+	ExpiredType string `json:"expired_type"`
+	// CanceledAmount is the cancelled amount.
+	CanceledAmount Decimal `json:"canceled_amount"`
+	// CanceledMarketBuyAmount is the cancelled JPY amount of the market buy order.
+	CanceledMarketBuyAmount Decimal `json:"canceled_market_buy_amount"`
+	// ExpiredAmount is the expired amount.
+	ExpiredAmount Decimal `json:"expired_amount"`
+	// ExpiredMarketBuyAmount is the expired JPY amount of the market buy order.
+	ExpiredMarketBuyAmount Decimal `json:"expired_market_buy_amount"`
+	// TimeInForce is the time in force of the order.
+	TimeInForce TimeInForce `json:"time_in_force"`
+	// EventTime is the time of the event.
+	EventTime Time `json:"event_time"`
+}
+
+// ExecutionEvent is an execution (fill) of your order received from the execution-events channel.
+// It has the same fields as Transaction returned by GetTransactions.
+type ExecutionEvent struct {
+	Transaction
+	// EventTime is the time of the event.
+	EventTime Time `json:"event_time"`
+}
+
+const (
+	// orderEventsChannel is the channel of the changes of your orders.
+	orderEventsChannel = "order-events"
+	// executionEventsChannel is the channel of the executions of your orders.
+	executionEventsChannel = "execution-events"
+	// loginTimeout is the maximum time to wait for the response of the login message.
+	loginTimeout = 10 * time.Second
+)
+
+// loginMessage is the message to authenticate the private WebSocket connection.
+type loginMessage struct {
+	Type            string `json:"type"`
+	AccessKey       string `json:"access_key"`
+	AccessNonce     string `json:"access_nonce"`
+	AccessSignature string `json:"access_signature"`
+}
+
+// privateSubscribeMessage is the message to subscribe to channels of the private WebSocket API.
+// e.g. {"type":"subscribe","channels":["order-events"]}
+type privateSubscribeMessage struct {
+	Type     string   `json:"type"`
+	Channels []string `json:"channels"`
+}
+
+// privateStreamResponse is the response of the login and subscribe messages, or the header of an event.
+type privateStreamResponse struct {
+	Type    string `json:"type"`
+	Channel string `json:"channel"`
+	Success *bool  `json:"success"`
+	Error   string `json:"error"`
+}
+
+// PrivateStreamInput represents the input parameter for NewPrivateStream.
+// The handlers are called from the goroutine that calls PrivateStream.Run, one message at a time.
+type PrivateStreamInput struct {
+	// OnOrder is called with each event of the order-events channel.
+	OnOrder func(OrderEvent)
+	// OnExecution is called with each event of the execution-events channel.
+	OnExecution func(ExecutionEvent)
+	// OnError is called when a message can not be decoded. The stream keeps running.
+	OnError func(error)
+	// Config configures the reconnection and the liveness detection. The zero value reconnects forever.
+	Config StreamConfig
+}
+
+// PrivateStream is a client of the Coincheck private WebSocket API (wss://stream.coincheck.com).
+// It logs in with the credentials of the client, and delivers the changes and executions of your orders.
+//
+// Subscribe to the channels with SubscribeOrderEvents and SubscribeExecutionEvents, then call Run.
+// The events while the connection is lost are not delivered again. Use GetTransactionsPagination
+// to fill the gap when StreamStateGapDetected is reported.
+type PrivateStream struct {
+	stream *stream
+	input  PrivateStreamInput
+	client *Client
+	url    *url.URL
+}
+
+// NewPrivateStream returns a new PrivateStream. It does not connect until Run is called.
+// API: wss://stream.coincheck.com
+// Visibility: Private
+// It returns ErrNoCredentials if the client does not have credentials.
+func (c *Client) NewPrivateStream(input PrivateStreamInput) (*PrivateStream, error) {
+	if !c.hasCredentials() {
+		return nil, ErrNoCredentials
+	}
+
+	s := &PrivateStream{input: input, client: c, url: c.privateWebSocketURL}
+	s.stream = &stream{
+		url:          c.privateWebSocketURL.String(),
+		dialer:       c.dialer,
+		config:       input.Config,
+		handle:       s.handle,
+		onError:      input.OnError,
+		authenticate: s.login,
+		newSubscribeMessage: func(channel string) any {
+			return privateSubscribeMessage{Type: "subscribe", Channels: []string{channel}}
+		},
+	}
+	return s, nil
+}
+
+// SubscribeOrderEvents subscribes to the order-events channel.
+func (s *PrivateStream) SubscribeOrderEvents() error {
+	return s.stream.subscribe(orderEventsChannel)
+}
+
+// SubscribeExecutionEvents subscribes to the execution-events channel.
+func (s *PrivateStream) SubscribeExecutionEvents() error {
+	return s.stream.subscribe(executionEventsChannel)
+}
+
+// Run connects and logs in to the WebSocket API, and delivers the events to the handlers until the context
+// is cancelled. When the connection is lost, it reconnects, logs in with a new nonce and subscribes to
+// all channels again (see StreamConfig). It returns ctx.Err() if the context is cancelled.
+// If the login is rejected, it returns an error that matches ErrUnauthorized without reconnecting.
+// Run must not be called concurrently.
+func (s *PrivateStream) Run(ctx context.Context) error {
+	return s.stream.run(ctx)
+}
+
+// login sends the login message signed with a new nonce and waits for the response.
+// The signature is HMAC-SHA256 of the nonce and the WebSocket URL.
+func (s *PrivateStream) login(conn *websocket.Conn) error {
+	headers, err := s.client.signRequest(s.url, "")
+	if err != nil {
+		return err
+	}
+	if err := conn.WriteJSON(loginMessage{
+		Type:            "login",
+		AccessKey:       headers.AccessKey,
+		AccessNonce:     headers.AccessNonce,
+		AccessSignature: headers.AccessSignature,
+	}); err != nil {
+		return withPrefixError(err)
+	}
+
+	if err := conn.SetReadDeadline(time.Now().Add(loginTimeout)); err != nil {
+		return withPrefixError(err)
+	}
+	var resp privateStreamResponse
+	if err := conn.ReadJSON(&resp); err != nil {
+		return withPrefixError(err)
+	}
+	if resp.Type != "login" || resp.Success == nil || !*resp.Success {
+		return fmt.Errorf("%w: login to the private WebSocket API failed: %s", ErrUnauthorized, resp.Error)
+	}
+	if err := conn.SetReadDeadline(time.Time{}); err != nil {
+		return withPrefixError(err)
+	}
+	return nil
+}
+
+// handle decodes a message of the private WebSocket API and calls the handler.
+// The responses of the subscribe messages are ignored.
+func (s *PrivateStream) handle(message []byte) error {
+	var header privateStreamResponse
+	if err := json.Unmarshal(message, &header); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidStreamMessage, string(message))
+	}
+
+	switch header.Channel {
+	case orderEventsChannel:
+		var event OrderEvent
+		if err := json.Unmarshal(message, &event); err != nil {
+			return fmt.Errorf("%w: order event: %w", ErrInvalidStreamMessage, err)
+		}
+		if s.input.OnOrder != nil {
+			s.input.OnOrder(event)
+		}
+	case executionEventsChannel:
+		var event ExecutionEvent
+		if err := json.Unmarshal(message, &event); err != nil {
+			return fmt.Errorf("%w: execution event: %w", ErrInvalidStreamMessage, err)
+		}
+		if s.input.OnExecution != nil {
+			s.input.OnExecution(event)
+		}
+	default:
+		if header.Success != nil && !*header.Success {
+			return fmt.Errorf("coincheck: %s failed: %s", header.Type, header.Error)
+		}
+	}
+	return nil
+}
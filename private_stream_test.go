@@ -0,0 +1,219 @@
+package coincheck
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/websocket"
+)
+
+func TestPrivateStream(t *testing.T) {
+	t.Run("PrivateStream logs in and delivers order and execution events", func(t *testing.T) {
+		var wsURL string
+		testServer := newWebSocketServer(t, func(conn *websocket.Conn) {
+			var login loginMessage
+			if err := conn.ReadJSON(&login); err != nil {
+				t.Error(err)
+				return
+			}
+			if diff := cmp.Diff("login", login.Type); diff != "" {
+				printDiff(t, diff)
+			}
+			if diff := cmp.Diff("api_key", login.AccessKey); diff != "" {
+				printDiff(t, diff)
+			}
+			if diff := cmp.Diff("1234", login.AccessNonce); diff != "" {
+				printDiff(t, diff)
+			}
+			h := hmac.New(sha256.New, []byte("api_secret"))
+			h.Write([]byte(login.AccessNonce + wsURL)) //nolint: errcheck // never returns an error
+			if diff := cmp.Diff(hex.EncodeToString(h.Sum(nil)), login.AccessSignature); diff != "" {
+				printDiff(t, diff)
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"login","success":true}`)); err != nil {
+				t.Error(err)
+				return
+			}
+
+			for _, want := range []string{orderEventsChannel, executionEventsChannel} {
+				var subscribe privateSubscribeMessage
+				if err := conn.ReadJSON(&subscribe); err != nil {
+					t.Error(err)
+					return
+				}
+				if diff := cmp.Diff(privateSubscribeMessage{Type: "subscribe", Channels: []string{want}}, subscribe); diff != "" {
+					printDiff(t, diff)
+				}
+			}
+
+			messages := []string{
+				`{"type":"subscribe","success":true}`,
+				`{"channel":"order-events","id":11453240,"pair":"btc_jpy","order_event":"PARTIALLY_FILL","order_type":"buy",` +
+					`"rate":"3000000.0","stop_loss_rate":null,"maker_fee_rate":"0.0","taker_fee_rate":"0.001","amount":"0.5",` +
+					`"market_buy_amount":null,"latest_executed_amount":"0.1","latest_executed_market_buy_amount":null,` +
+					`"expired_type":null,"canceled_amount":null,"canceled_market_buy_amount":null,"expired_amount":null,` +
+					`"expired_market_buy_amount":null,"time_in_force":"good_til_cancelled","event_time":"2022-09-20T13:30:00.000Z"}`,
+				`{"channel":"execution-events","id":38,"order_id":11453240,"event_time":"2022-09-20T13:30:00.000Z",` +
+					`"funds":{"btc":"0.1","jpy":"-300000.0"},"pair":"btc_jpy","rate":"3000000.0","fee_currency":"JPY",` +
+					`"fee":"300.0","liquidity":"T","side":"buy"}`,
+			}
+			for _, m := range messages {
+				if err := conn.WriteMessage(websocket.TextMessage, []byte(m)); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+			conn.ReadMessage() //nolint: errcheck // wait for the client to close
+		})
+		defer testServer.Close()
+		wsURL = webSocketURL(testServer)
+
+		client, err := NewClient(
+			WithPrivateWebSocketURL(wsURL),
+			WithCredentials("api_key", "api_secret"),
+			WithNonceSource(&stubNonceSource{nonce: 1234}),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			orders     []OrderEvent
+			executions []ExecutionEvent
+		)
+		s, err := client.NewPrivateStream(PrivateStreamInput{
+			OnOrder: func(e OrderEvent) {
+				orders = append(orders, e)
+			},
+			OnExecution: func(e ExecutionEvent) {
+				executions = append(executions, e)
+				cancel()
+			},
+			OnError: func(err error) {
+				t.Error(err)
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.SubscribeOrderEvents(); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.SubscribeExecutionEvents(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := s.Run(ctx); !errors.Is(err, context.Canceled) {
+			t.Fatalf("error is not context.Canceled: %v", err)
+		}
+
+		eventTime := NewTime(time.Date(2022, 9, 20, 13, 30, 0, 0, time.UTC))
+		wantOrders := []OrderEvent{
+			{
+				ID:                   11453240,
+				Pair:                 PairBTCJPY,
+				OrderEvent:           OrderEventPartiallyFilled,
+				OrderType:            OrderTypeBuy,
+				Rate:                 MustParseDecimal("3000000.0"),
+				MakerFeeRate:         MustParseDecimal("0.0"),
+				TakerFeeRate:         MustParseDecimal("0.001"),
+				Amount:               MustParseDecimal("0.5"),
+				LatestExecutedAmount: MustParseDecimal("0.1"),
+				TimeInForce:          TimeInForceGoodTilCancelled,
+				EventTime:            eventTime,
+			},
+		}
+		if diff := cmp.Diff(wantOrders, orders, equateTime); diff != "" {
+			printDiff(t, diff)
+		}
+
+		wantExecutions := []ExecutionEvent{
+			{
+				Transaction: Transaction{
+					ID:          38,
+					OrderID:     11453240,
+					Funds:       map[Currency]Decimal{CurrencyBTC: MustParseDecimal("0.1"), CurrencyJPY: MustParseDecimal("-300000.0")},
+					Pair:        PairBTCJPY,
+					Rate:        MustParseDecimal("3000000.0"),
+					FeeCurrency: CurrencyJPY,
+					Fee:         MustParseDecimal("300.0"),
+					Liquidity:   LiquidityTaker,
+					Side:        OrderTypeBuy,
+				},
+				EventTime: eventTime,
+			},
+		}
+		if diff := cmp.Diff(wantExecutions, executions, equateTime); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("PrivateStream returns ErrUnauthorized if the login is rejected", func(t *testing.T) {
+		testServer := newWebSocketServer(t, func(conn *websocket.Conn) {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				t.Error(err)
+				return
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"login","success":false,"error":"invalid signature"}`)); err != nil {
+				t.Error(err)
+			}
+		})
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithPrivateWebSocketURL(webSocketURL(testServer)),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s, err := client.NewPrivateStream(PrivateStreamInput{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Run(context.Background()); !errors.Is(err, ErrUnauthorized) {
+			t.Errorf("error is not ErrUnauthorized: %v", err)
+		}
+	})
+
+	t.Run("NewPrivateStream returns an error if client does not set credentials", func(t *testing.T) {
+		client, err := NewClient()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := client.NewPrivateStream(PrivateStreamInput{}); !errors.Is(err, ErrNoCredentials) {
+			t.Errorf("error is not ErrNoCredentials: %v", err)
+		}
+	})
+}
+
+func TestOrderEventType_OrderStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		event OrderEventType
+		want  OrderStatus
+	}{
+		{event: OrderEventNew, want: OrderStatusNew},
+		{event: OrderEventPartiallyFilled, want: OrderStatusPartiallyFilled},
+		{event: OrderEventFilled, want: OrderStatusFilled},
+		{event: OrderEventCanceled, want: OrderStatusCanceled},
+		{event: OrderEventExpired, want: OrderStatusExpired},
+	}
+	for _, tt := range tests {
+		if got := tt.event.OrderStatus(); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.event, got, tt.want)
+		}
+	}
+}
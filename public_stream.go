@@ -0,0 +1,173 @@
+package coincheck
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// TradeEvent is a trade received from the "[pair]-trades" channel.
+type TradeEvent struct {
+	Trade
+	// TakerOrderID is the order ID of the taker.
+	TakerOrderID int
+	// MakerOrderID is the order ID of the maker.
+	MakerOrderID int
+}
+
+// OrderBookEvent is a difference of the order book received from the "[pair]-orderbook" channel.
+// A level whose amount is 0 has been removed from the order book.
+type OrderBookEvent struct {
+	// Pair is the pair of the currency.
+	Pair Pair `json:"-"`
+	// Bids is the changed buy orders.
+	Bids []PriceLevel `json:"bids"`
+	// Asks is the changed sell orders.
+	Asks []PriceLevel `json:"asks"`
+	// LastUpdateAt is the time of the last update of the order book.
+	LastUpdateAt Time `json:"last_update_at"`
+}
+
+// PublicStreamInput represents the input parameter for NewPublicStream.
+// The handlers are called from the goroutine that calls PublicStream.Run, one message at a time.
+type PublicStreamInput struct {
+	// OnTrade is called with each trade of the subscribed trades channels.
+	OnTrade func(TradeEvent)
+	// OnOrderBook is called with each difference of the subscribed orderbook channels.
+	OnOrderBook func(OrderBookEvent)
+	// OnError is called when a message can not be decoded. The stream keeps running.
+	OnError func(error)
+	// Config configures the reconnection and the liveness detection. The zero value reconnects forever.
+	Config StreamConfig
+}
+
+// PublicStream is a client of the Coincheck public WebSocket API (wss://ws-api.coincheck.com/).
+// It delivers trades and order book differences of the subscribed channels.
+//
+// Subscribe to the channels with SubscribeTrades and SubscribeOrderBook, then call Run.
+// The subscribe methods can also be called while Run is running.
+type PublicStream struct {
+	stream *stream
+	input  PublicStreamInput
+}
+
+// NewPublicStream returns a new PublicStream. It does not connect until Run is called.
+// API: wss://ws-api.coincheck.com/
+// Visibility: Public
+func (c *Client) NewPublicStream(input PublicStreamInput) *PublicStream {
+	s := &PublicStream{input: input}
+	s.stream = &stream{
+		url:     c.publicWebSocketURL.String(),
+		dialer:  c.dialer,
+		config:  input.Config,
+		handle:  s.handle,
+		onError: input.OnError,
+	}
+	return s
+}
+
+// SubscribeTrades subscribes to the "[pair]-trades" channel.
+func (s *PublicStream) SubscribeTrades(pair Pair) error {
+	return s.stream.subscribe(pair.String() + "-trades")
+}
+
+// SubscribeOrderBook subscribes to the "[pair]-orderbook" channel.
+func (s *PublicStream) SubscribeOrderBook(pair Pair) error {
+	return s.stream.subscribe(pair.String() + "-orderbook")
+}
+
+// Run connects to the WebSocket API and delivers the events to the handlers until the context is cancelled.
+// When the connection is lost, it reconnects and subscribes to all channels again (see StreamConfig).
+// It returns ctx.Err() if the context is cancelled, or the last error if it gives up reconnecting.
+// Run must not be called concurrently.
+func (s *PublicStream) Run(ctx context.Context) error {
+	return s.stream.run(ctx)
+}
+
+// handle decodes a message of the public WebSocket API and calls the handler.
+//
+// Trades: [["1663318663","2357062","btc_jpy","2820896.0","5.0","sell","1193401","2078767"], ...]
+// Order book: ["btc_jpy",{"bids":[["148634.0","0"]],"asks":[],"last_update_at":"1659321701"}]
+func (s *PublicStream) handle(message []byte) error {
+	var fields []json.RawMessage
+	if err := json.Unmarshal(message, &fields); err != nil || len(fields) == 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidStreamMessage, string(message))
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(fields[0]), []byte("[")) {
+		for _, field := range fields {
+			trade, err := unmarshalTradeEvent(field)
+			if err != nil {
+				return err
+			}
+			if s.input.OnTrade != nil {
+				s.input.OnTrade(trade)
+			}
+		}
+		return nil
+	}
+
+	event, err := unmarshalOrderBookEvent(fields)
+	if err != nil {
+		return err
+	}
+	if s.input.OnOrderBook != nil {
+		s.input.OnOrderBook(event)
+	}
+	return nil
+}
+
+// unmarshalTradeEvent decodes [timestamp, id, pair, rate, amount, order_type, taker_id, maker_id].
+func unmarshalTradeEvent(raw json.RawMessage) (TradeEvent, error) {
+	var fields []json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil || len(fields) < 8 {
+		return TradeEvent{}, fmt.Errorf("%w: trade: %s", ErrInvalidStreamMessage, string(raw))
+	}
+
+	var (
+		event TradeEvent
+		err   error
+	)
+	if err := json.Unmarshal(fields[0], &event.CreatedAt); err != nil {
+		return TradeEvent{}, fmt.Errorf("%w: trade timestamp: %w", ErrInvalidStreamMessage, err)
+	}
+	if event.ID, err = unmarshalStreamInt(fields[1]); err != nil {
+		return TradeEvent{}, fmt.Errorf("%w: trade id: %w", ErrInvalidStreamMessage, err)
+	}
+	if err := json.Unmarshal(fields[2], &event.Pair); err != nil {
+		return TradeEvent{}, fmt.Errorf("%w: trade pair: %w", ErrInvalidStreamMessage, err)
+	}
+	if err := json.Unmarshal(fields[3], &event.Rate); err != nil {
+		return TradeEvent{}, fmt.Errorf("%w: trade rate: %w", ErrInvalidStreamMessage, err)
+	}
+	if err := json.Unmarshal(fields[4], &event.Amount); err != nil {
+		return TradeEvent{}, fmt.Errorf("%w: trade amount: %w", ErrInvalidStreamMessage, err)
+	}
+	if err := json.Unmarshal(fields[5], &event.OrderType); err != nil {
+		return TradeEvent{}, fmt.Errorf("%w: trade order type: %w", ErrInvalidStreamMessage, err)
+	}
+	if event.TakerOrderID, err = unmarshalStreamInt(fields[6]); err != nil {
+		return TradeEvent{}, fmt.Errorf("%w: trade taker id: %w", ErrInvalidStreamMessage, err)
+	}
+	if event.MakerOrderID, err = unmarshalStreamInt(fields[7]); err != nil {
+		return TradeEvent{}, fmt.Errorf("%w: trade maker id: %w", ErrInvalidStreamMessage, err)
+	}
+	return event, nil
+}
+
+// unmarshalOrderBookEvent decodes ["btc_jpy", {"bids": ..., "asks": ..., "last_update_at": ...}].
+func unmarshalOrderBookEvent(fields []json.RawMessage) (OrderBookEvent, error) {
+	if len(fields) != 2 {
+		return OrderBookEvent{}, fmt.Errorf("%w: order book: unexpected %d fields", ErrInvalidStreamMessage, len(fields))
+	}
+
+	var event OrderBookEvent
+	if err := json.Unmarshal(fields[0], &event.Pair); err != nil {
+		return OrderBookEvent{}, fmt.Errorf("%w: order book pair: %w", ErrInvalidStreamMessage, err)
+	}
+	if err := json.Unmarshal(fields[1], &event); err != nil {
+		return OrderBookEvent{}, fmt.Errorf("%w: order book: %w", ErrInvalidStreamMessage, err)
+	}
+	return event, nil
+}
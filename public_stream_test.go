@@ -0,0 +1,144 @@
+package coincheck
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/websocket"
+)
+
+func TestPublicStream(t *testing.T) {
+	t.Run("PublicStream delivers trades and order book differences", func(t *testing.T) {
+		testServer := newWebSocketServer(t, func(conn *websocket.Conn) {
+			if diff := cmp.Diff("btc_jpy-trades", readSubscribe(t, conn)); diff != "" {
+				printDiff(t, diff)
+			}
+			if diff := cmp.Diff("btc_jpy-orderbook", readSubscribe(t, conn)); diff != "" {
+				printDiff(t, diff)
+			}
+
+			messages := []string{
+				`[["1663318663","2357062","btc_jpy","2820896.0","5.0","sell","1193401","2078767"],` +
+					`["1663318664","2357063","btc_jpy","2820897.0","0.5","buy","1193402","2078768"]]`,
+				`not json`,
+				`["btc_jpy",{"bids":[["148634.0","0"],["148633.0","0.0031"]],"asks":[["148834.0","0.0152"]],"last_update_at":"1659321701"}]`,
+			}
+			for _, m := range messages {
+				if err := conn.WriteMessage(websocket.TextMessage, []byte(m)); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+			conn.ReadMessage() //nolint: errcheck // wait for the client to close
+		})
+		defer testServer.Close()
+
+		client, err := NewClient(WithPublicWebSocketURL(webSocketURL(testServer)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			trades []TradeEvent
+			books  []OrderBookEvent
+			errs   []error
+		)
+		s := client.NewPublicStream(PublicStreamInput{
+			OnTrade: func(e TradeEvent) {
+				trades = append(trades, e)
+			},
+			OnOrderBook: func(e OrderBookEvent) {
+				books = append(books, e)
+				cancel()
+			},
+			OnError: func(err error) {
+				errs = append(errs, err)
+			},
+		})
+		if err := s.SubscribeTrades(PairBTCJPY); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.SubscribeOrderBook(PairBTCJPY); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := s.Run(ctx); !errors.Is(err, context.Canceled) {
+			t.Fatalf("error is not context.Canceled: %v", err)
+		}
+
+		wantTrades := []TradeEvent{
+			{
+				Trade: Trade{
+					ID:        2357062,
+					Amount:    MustParseDecimal("5.0"),
+					Rate:      MustParseDecimal("2820896.0"),
+					Pair:      PairBTCJPY,
+					OrderType: OrderTypeSell,
+					CreatedAt: NewTime(time.Unix(1663318663, 0)),
+				},
+				TakerOrderID: 1193401,
+				MakerOrderID: 2078767,
+			},
+			{
+				Trade: Trade{
+					ID:        2357063,
+					Amount:    MustParseDecimal("0.5"),
+					Rate:      MustParseDecimal("2820897.0"),
+					Pair:      PairBTCJPY,
+					OrderType: OrderTypeBuy,
+					CreatedAt: NewTime(time.Unix(1663318664, 0)),
+				},
+				TakerOrderID: 1193402,
+				MakerOrderID: 2078768,
+			},
+		}
+		if diff := cmp.Diff(wantTrades, trades, equateTime); diff != "" {
+			printDiff(t, diff)
+		}
+
+		wantBooks := []OrderBookEvent{
+			{
+				Pair: PairBTCJPY,
+				Bids: []PriceLevel{
+					{Price: MustParseDecimal("148634.0"), Amount: MustParseDecimal("0")},
+					{Price: MustParseDecimal("148633.0"), Amount: MustParseDecimal("0.0031")},
+				},
+				Asks: []PriceLevel{
+					{Price: MustParseDecimal("148834.0"), Amount: MustParseDecimal("0.0152")},
+				},
+				LastUpdateAt: NewTime(time.Unix(1659321701, 0)),
+			},
+		}
+		if diff := cmp.Diff(wantBooks, books, equateTime); diff != "" {
+			printDiff(t, diff)
+		}
+
+		if len(errs) != 1 || !errors.Is(errs[0], ErrInvalidStreamMessage) {
+			t.Errorf("want one ErrInvalidStreamMessage, but got %v", errs)
+		}
+	})
+
+	t.Run("PublicStream reports an invalid trade", func(t *testing.T) {
+		client, err := NewClient()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s := client.NewPublicStream(PublicStreamInput{})
+		if err := s.handle([]byte(`[["1663318663","x","btc_jpy","1","1","sell","1","2"]]`)); !errors.Is(err, ErrInvalidStreamMessage) {
+			t.Errorf("error is not ErrInvalidStreamMessage: %v", err)
+		}
+		if err := s.handle([]byte(`[["1663318663","1","btc_jpy"]]`)); !errors.Is(err, ErrInvalidStreamMessage) {
+			t.Errorf("error is not ErrInvalidStreamMessage: %v", err)
+		}
+		if err := s.handle([]byte(`["btc_jpy"]`)); !errors.Is(err, ErrInvalidStreamMessage) {
+			t.Errorf("error is not ErrInvalidStreamMessage: %v", err)
+		}
+	})
+}
@@ -3,7 +3,6 @@ package coincheck
 import (
 	"context"
 	"errors"
-	"fmt"
 	"net/http"
 )
 
@@ -16,7 +15,7 @@ type GetRateInput struct {
 // GetRateResponse represents the output from GetStandardRate.
 type GetRateResponse struct {
 	// Rate is the standard rate.
-	Rate string `json:"rate"`
+	Rate Decimal `json:"rate"`
 }
 
 // GetRate returns the standard rate.
@@ -54,9 +53,9 @@ type GetExchangeOrdersRateInput struct {
 	// Specify a currency pair to trade. btc_jpy, etc_jpy, lsk_jpy, mona_jpy, plt_jpy, fnct_jpy, dai_jpy, wbtc_jpy, bril_jpy are now available.
 	Pair Pair
 	// Price is the price of the order. e.g. 30000.
-	Price *float64
+	Price *Decimal
 	// Amount is the amount of the order. e.g. 0.1.
-	Amount *float64
+	Amount *Decimal
 }
 
 // GetExchangeOrdersRateResponse represents the output from GetExchangeOrdersRate.
@@ -64,11 +63,11 @@ type GetExchangeOrdersRateResponse struct {
 	// Success is a boolean value that indicates the success of the API call.
 	Success bool `json:"success"`
 	// Rate is the rate of the order.
-	Rate string `json:"rate"`
+	Rate Decimal `json:"rate"`
 	// Price is the price of the order.
-	Price string `json:"price"`
+	Price Decimal `json:"price"`
 	// Amount is the amount of the order.
-	Amount string `json:"amount"`
+	Amount Decimal `json:"amount"`
 }
 
 // GetExchangeOrdersRate calculate the rate from the order of the exchange.
@@ -83,9 +82,9 @@ func (c *Client) GetExchangeOrdersRate(ctx context.Context, input GetExchangeOrd
 		return nil, withPrefixError(errors.New("either price or amount must be specified as a parameter"))
 	}
 	if input.Price != nil {
-		queryParam["price"] = fmt.Sprintf("%f", *input.Price)
+		queryParam["price"] = input.Price.String()
 	} else {
-		queryParam["amount"] = fmt.Sprintf("%f", *input.Amount)
+		queryParam["amount"] = input.Amount.String()
 	}
 
 	req, err := c.createRequest(ctx, createRequestInput{
@@ -25,7 +25,7 @@ func TestClient_GetStandardRate(t *testing.T) {
 			}
 
 			result := GetRateResponse{
-				Rate: "1000000",
+				Rate: MustParseDecimal("1000000"),
 			}
 			if err := json.NewEncoder(w).Encode(result); err != nil {
 				t.Fatal(err)
@@ -42,7 +42,7 @@ func TestClient_GetStandardRate(t *testing.T) {
 			t.Fatal(err)
 		}
 		want := &GetRateResponse{
-			Rate: "1000000",
+			Rate: MustParseDecimal("1000000"),
 		}
 		if diff := cmp.Diff(want, got); diff != "" {
 			printDiff(t, diff)
@@ -77,7 +77,7 @@ func TestClient_GetStandardRate(t *testing.T) {
 			}
 
 			result := GetRateResponse{
-				Rate: "1000000",
+				Rate: MustParseDecimal("1000000"),
 			}
 			if err := json.NewEncoder(w).Encode(result); err != nil {
 				t.Fatal(err)
@@ -94,7 +94,7 @@ func TestClient_GetStandardRate(t *testing.T) {
 			t.Fatal(err)
 		}
 		want := &GetRateResponse{
-			Rate: "1000000",
+			Rate: MustParseDecimal("1000000"),
 		}
 		if diff := cmp.Diff(want, got); diff != "" {
 			printDiff(t, diff)
@@ -123,16 +123,16 @@ func TestClient_GetExchangeOrdersRate(t *testing.T) {
 			if got := r.URL.Query().Get("order_type"); got != wantOrderType.String() {
 				t.Errorf("order_type: got %v, want %v", got, wantOrderType)
 			}
-			wantAmount := "1.200000"
+			wantAmount := "1.2"
 			if got := r.URL.Query().Get("amount"); got != wantAmount {
 				t.Errorf("amount: got %v, want %v", got, wantAmount)
 			}
 
 			result := GetExchangeOrdersRateResponse{
 				Success: true,
-				Rate:    "9118315.44305",
-				Price:   "10941978.53166054",
-				Amount:  "1.2",
+				Rate:    MustParseDecimal("9118315.44305"),
+				Price:   MustParseDecimal("10941978.53166054"),
+				Amount:  MustParseDecimal("1.2"),
 			}
 			if err := json.NewEncoder(w).Encode(result); err != nil {
 				t.Fatal(err)
@@ -149,7 +149,7 @@ func TestClient_GetExchangeOrdersRate(t *testing.T) {
 		input := GetExchangeOrdersRateInput{
 			OrderType: OrderTypeBuy,
 			Pair:      PairBTCJPY,
-			Amount:    pointer.Float64(1.2),
+			Amount:    pointer.Ptr(MustParseDecimal("1.2")),
 		}
 		got, err := client.GetExchangeOrdersRate(context.Background(), input)
 		if err != nil {
@@ -157,9 +157,9 @@ func TestClient_GetExchangeOrdersRate(t *testing.T) {
 		}
 		want := &GetExchangeOrdersRateResponse{
 			Success: true,
-			Rate:    "9118315.44305",
-			Price:   "10941978.53166054",
-			Amount:  "1.2",
+			Rate:    MustParseDecimal("9118315.44305"),
+			Price:   MustParseDecimal("10941978.53166054"),
+			Amount:  MustParseDecimal("1.2"),
 		}
 		if diff := cmp.Diff(want, got); diff != "" {
 			printDiff(t, diff)
@@ -186,16 +186,16 @@ func TestClient_GetExchangeOrdersRate(t *testing.T) {
 			if got := r.URL.Query().Get("order_type"); got != wantOrderType.String() {
 				t.Errorf("order_type: got %v, want %v", got, wantOrderType)
 			}
-			wantPrice := "1200000.000000"
+			wantPrice := "1200000"
 			if got := r.URL.Query().Get("price"); got != wantPrice {
 				t.Errorf("price: got %v, want %v", got, wantPrice)
 			}
 
 			result := GetExchangeOrdersRateResponse{
 				Success: true,
-				Rate:    "9118315.44305",
-				Price:   "1200000",
-				Amount:  "1.1",
+				Rate:    MustParseDecimal("9118315.44305"),
+				Price:   MustParseDecimal("1200000"),
+				Amount:  MustParseDecimal("1.1"),
 			}
 			if err := json.NewEncoder(w).Encode(result); err != nil {
 				t.Fatal(err)
@@ -212,7 +212,7 @@ func TestClient_GetExchangeOrdersRate(t *testing.T) {
 		input := GetExchangeOrdersRateInput{
 			OrderType: OrderTypeBuy,
 			Pair:      PairBTCJPY,
-			Price:     pointer.Float64(1200000),
+			Price:     pointer.Ptr(MustParseDecimal("1200000")),
 		}
 		got, err := client.GetExchangeOrdersRate(context.Background(), input)
 		if err != nil {
@@ -220,9 +220,9 @@ func TestClient_GetExchangeOrdersRate(t *testing.T) {
 		}
 		want := &GetExchangeOrdersRateResponse{
 			Success: true,
-			Rate:    "9118315.44305",
-			Price:   "1200000",
-			Amount:  "1.1",
+			Rate:    MustParseDecimal("9118315.44305"),
+			Price:   MustParseDecimal("1200000"),
+			Amount:  MustParseDecimal("1.1"),
 		}
 		if diff := cmp.Diff(want, got); diff != "" {
 			printDiff(t, diff)
@@ -253,7 +253,7 @@ func TestClient_GetExchangeOrdersRate(t *testing.T) {
 		if _, err = client.GetExchangeOrdersRate(context.Background(), GetExchangeOrdersRateInput{
 			OrderType: OrderTypeBuy,
 			Pair:      PairBTCJPY,
-			Amount:    pointer.Float64(1.2),
+			Amount:    pointer.Ptr(MustParseDecimal("1.2")),
 		}); err == nil {
 			t.Fatal("expected an error, but got nil")
 		}
@@ -0,0 +1,230 @@
+package coincheck
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// SendMoneyStatus represents the status of a crypto transfer.
+type SendMoneyStatus string
+
+// String returns the string representation of the SendMoneyStatus.
+func (s SendMoneyStatus) String() string {
+	return string(s)
+}
+
+const (
+	// SendMoneyStatusPending means the transfer is waiting to be processed.
+	SendMoneyStatusPending SendMoneyStatus = "pending"
+	// SendMoneyStatusProcessing means the transfer is being processed.
+	SendMoneyStatusProcessing SendMoneyStatus = "processing"
+	// SendMoneyStatusFinished means the transfer was sent.
+	SendMoneyStatusFinished SendMoneyStatus = "finished"
+	// SendMoneyStatusCanceled means the transfer was cancelled.
+	SendMoneyStatusCanceled SendMoneyStatus = "canceled"
+)
+
+// SendMoneyConfirmer approves a crypto transfer before SendMoney signs and sends it.
+// If it returns an error, the transfer is not sent and SendMoney returns an error that matches
+// ErrSendMoneyNotConfirmed. e.g. Ask an operator, or check the address against an allow list.
+type SendMoneyConfirmer func(ctx context.Context, input SendMoneyInput) error
+
+// SendMoneyInput represents the input parameter for the SendMoney method.
+type SendMoneyInput struct {
+	// Address is the destination address.
+	Address string
+	// Amount is the amount to send. e.g. 0.0002
+	Amount Decimal
+	// Currency is the currency to send. e.g. btc
+	Currency Currency
+}
+
+// validate validates the SendMoneyInput.
+func (i SendMoneyInput) validate() error {
+	if i.Currency == "" {
+		return fmt.Errorf("%w: currency is required", ErrInvalidSendMoney)
+	}
+	if i.Currency == CurrencyJPY {
+		return fmt.Errorf("%w: jpy can not be sent", ErrInvalidSendMoney)
+	}
+	if i.Amount.Sign() <= 0 {
+		return fmt.Errorf("%w: amount must be greater than 0", ErrInvalidSendMoney)
+	}
+	if !validAddress(i.Currency, i.Address) {
+		return fmt.Errorf("%w: invalid %s address %q", ErrInvalidSendMoney, i.Currency, i.Address)
+	}
+	return nil
+}
+
+// ethereumAddressPattern is the address format of Ethereum and the ERC-20 tokens.
+var ethereumAddressPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+
+// addressPatterns is the address format of each currency.
+// A bech32 address is either all lower case or all upper case (BIP 173), e.g. in a QR code.
+var addressPatterns = map[Currency]*regexp.Regexp{
+	// P2PKH (1...), P2SH (3...) and bech32 (bc1...).
+	CurrencyBTC:  regexp.MustCompile(`^([13][1-9A-HJ-NP-Za-km-z]{25,34}|bc1[02-9ac-hj-np-z]{11,71}|BC1[02-9AC-HJ-NP-Z]{11,71})$`),
+	CurrencyETH:  ethereumAddressPattern,
+	CurrencyETC:  ethereumAddressPattern,
+	CurrencyPlt:  ethereumAddressPattern,
+	CurrencyFnct: ethereumAddressPattern,
+	CurrencyDai:  ethereumAddressPattern,
+	CurrencyWbtc: ethereumAddressPattern,
+	CurrencyBril: ethereumAddressPattern,
+	// Lisk 3 (lsk...) and legacy (digits + L).
+	CurrencyLsk: regexp.MustCompile(`^(lsk[a-z2-9]{38}|[0-9]{1,20}L)$`),
+	// P2PKH (M...), P2SH (P... or 3...) and bech32 (mona1...).
+	CurrencyMona: regexp.MustCompile(`^([MP3][1-9A-HJ-NP-Za-km-z]{25,34}|mona1[02-9ac-hj-np-z]{11,71}|MONA1[02-9AC-HJ-NP-Z]{11,71})$`),
+}
+
+// validAddress returns true if the address has the format of the currency.
+// The address of an unknown currency is only checked that it's not empty and has no space.
+func validAddress(currency Currency, address string) bool {
+	if address == "" || strings.ContainsAny(address, " \t\r\n") {
+		return false
+	}
+
+	pattern, ok := addressPatterns[currency]
+	if !ok {
+		return true
+	}
+	return pattern.MatchString(address)
+}
+
+// sendMoneyRequestBody is the request body for POST /api/send_money.
+type sendMoneyRequestBody struct {
+	Address  string   `json:"address"`
+	Amount   string   `json:"amount"`
+	Currency Currency `json:"currency"`
+}
+
+// SendMoneyResponse represents the output from the SendMoney method.
+type SendMoneyResponse struct {
+	// Success is a boolean value that indicates the success of the API call.
+	Success bool `json:"success"`
+	// ID is the ID of the transfer.
+	ID int `json:"id,string"`
+	// Address is the destination address.
+	Address string `json:"address"`
+	// Amount is the amount sent.
+	Amount Decimal `json:"amount"`
+	// Fee is the fee of the transfer in the sent currency.
+	Fee Decimal `json:"fee"`
+}
+
+// SendMoney sends crypto to the address.
+// API: POST /api/send_money
+// Visibility: Private
+// The input is validated (including the address format of the currency) before signing the request,
+// and then it's approved by the SendMoneyConfirmer of the client (see WithSendMoneyConfirmer).
+// If the client does not have a SendMoneyConfirmer, it returns ErrSendMoneyNotConfirmed without calling the Coincheck API.
+func (c *Client) SendMoney(ctx context.Context, input SendMoneyInput) (*SendMoneyResponse, error) {
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+	if c.sendMoneyConfirmer == nil {
+		return nil, fmt.Errorf("%w: use WithSendMoneyConfirmer", ErrSendMoneyNotConfirmed)
+	}
+	if err := c.sendMoneyConfirmer(ctx, input); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSendMoneyNotConfirmed, err)
+	}
+
+	req, err := c.createRequest(ctx, createRequestInput{
+		method: http.MethodPost,
+		path:   "/api/send_money",
+		body: sendMoneyRequestBody{
+			Address:  input.Address,
+			Amount:   input.Amount.String(),
+			Currency: input.Currency,
+		},
+		private: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var output SendMoneyResponse
+	if err := c.do(req, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// SendMoney represents a crypto transfer in the history.
+type SendMoney struct {
+	// ID is the ID of the transfer.
+	ID int `json:"id"`
+	// Amount is the amount sent.
+	Amount Decimal `json:"amount"`
+	// Currency is the currency sent.
+	Currency Currency `json:"currency"`
+	// Fee is the fee of the transfer in Currency.
+	Fee Decimal `json:"fee"`
+	// Address is the destination address.
+	Address string `json:"address"`
+	// Status is the status of the transfer. It may be empty.
+	Status SendMoneyStatus `json:"status"`
+	// CreatedAt is the creation time of the transfer.
+	CreatedAt Time `json:"created_at"`
+}
+
+// GetSendMoneyHistoryInput represents the input parameter for the GetSendMoneyHistory method.
+type GetSendMoneyHistoryInput struct {
+	// Currency is the currency of the transfers. e.g. btc. It's required.
+	Currency Currency
+	// Pagination is the pagination of the data. If you don't set it, the Coincheck API uses its default values.
+	Pagination Pagination
+}
+
+// GetSendMoneyHistoryResponse represents the output from the GetSendMoneyHistory method.
+type GetSendMoneyHistoryResponse struct {
+	// Success is a boolean value that indicates the success of the API call.
+	Success bool `json:"success"`
+	// Pagination is the pagination of the data.
+	Pagination Pagination `json:"pagination"`
+	// Sends is a list of your crypto transfers.
+	Sends []SendMoney `json:"sends"`
+}
+
+// GetSendMoneyHistory returns the history of your crypto transfers of the currency.
+// API: GET /api/send_money
+// Visibility: Private
+// If you want to get all transfers, use NewSendMoneyPaginator.
+func (c *Client) GetSendMoneyHistory(ctx context.Context, input GetSendMoneyHistoryInput) (*GetSendMoneyHistoryResponse, error) {
+	if input.Currency == "" {
+		return nil, fmt.Errorf("%w: currency is required", ErrInvalidSendMoney)
+	}
+
+	queryParam := input.Pagination.queryParam()
+	queryParam["currency"] = strings.ToUpper(input.Currency.String())
+	req, err := c.createRequest(ctx, createRequestInput{
+		method:     http.MethodGet,
+		path:       "/api/send_money",
+		queryParam: queryParam,
+		private:    true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var output GetSendMoneyHistoryResponse
+	if err := c.do(req, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// NewSendMoneyPaginator returns a Paginator over GetSendMoneyHistory of the currency.
+func (c *Client) NewSendMoneyPaginator(currency Currency, input PaginatorInput) *Paginator[SendMoney] {
+	fetch := func(ctx context.Context, pagination Pagination) ([]SendMoney, error) {
+		resp, err := c.GetSendMoneyHistory(ctx, GetSendMoneyHistoryInput{Currency: currency, Pagination: pagination})
+		if err != nil {
+			return nil, err
+		}
+		return resp.Sends, nil
+	}
+	return NewPaginator(fetch, func(s SendMoney) int { return s.ID }, input)
+}
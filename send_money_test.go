@@ -0,0 +1,258 @@
+package coincheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_SendMoney(t *testing.T) {
+	t.Run("SendMoney sends crypto after the confirmation", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			wantMethod := http.MethodPost
+			if diff := cmp.Diff(wantMethod, r.Method); diff != "" {
+				printDiff(t, diff)
+			}
+
+			wantEndpoint := "/api/send_money"
+			if diff := cmp.Diff(wantEndpoint, r.URL.Path); diff != "" {
+				printDiff(t, diff)
+			}
+
+			var body map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			wantBody := map[string]string{
+				"address":  "1v6zFvyNPgdRvhUufkRoTtgyiw1xigncc",
+				"amount":   "1.5",
+				"currency": "btc",
+			}
+			if diff := cmp.Diff(wantBody, body); diff != "" {
+				printDiff(t, diff)
+			}
+
+			w.Write([]byte(`{"success":true,"id":"276","address":"1v6zFvyNPgdRvhUufkRoTtgyiw1xigncc","amount":"1.5","fee":"0.002"}`)) //nolint: errcheck // ignore error
+		}))
+		defer testServer.Close()
+
+		var confirmed SendMoneyInput
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+			WithSendMoneyConfirmer(func(_ context.Context, input SendMoneyInput) error {
+				confirmed = input
+				return nil
+			}),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		input := SendMoneyInput{
+			Address:  "1v6zFvyNPgdRvhUufkRoTtgyiw1xigncc",
+			Amount:   MustParseDecimal("1.5"),
+			Currency: CurrencyBTC,
+		}
+		got, err := client.SendMoney(context.Background(), input)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := &SendMoneyResponse{
+			Success: true,
+			ID:      276,
+			Address: "1v6zFvyNPgdRvhUufkRoTtgyiw1xigncc",
+			Amount:  MustParseDecimal("1.5"),
+			Fee:     MustParseDecimal("0.002"),
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			printDiff(t, diff)
+		}
+		if diff := cmp.Diff(input, confirmed); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("SendMoney does not send the request without the confirmation", func(t *testing.T) {
+		var called atomic.Int32
+		testServer := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+			called.Add(1)
+		}))
+		defer testServer.Close()
+
+		input := SendMoneyInput{
+			Address:  "0x52908400098527886E0F7030069857D2E4169EE7",
+			Amount:   MustParseDecimal("0.1"),
+			Currency: CurrencyETH,
+		}
+
+		client, err := NewClient(WithBaseURL(testServer.URL), WithCredentials("api_key", "api_secret"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := client.SendMoney(context.Background(), input); !errors.Is(err, ErrSendMoneyNotConfirmed) {
+			t.Errorf("error is not ErrSendMoneyNotConfirmed: %v", err)
+		}
+
+		errRejected := errors.New("rejected by operator")
+		client, err = NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+			WithSendMoneyConfirmer(func(context.Context, SendMoneyInput) error { return errRejected }),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = client.SendMoney(context.Background(), input)
+		if !errors.Is(err, ErrSendMoneyNotConfirmed) || !errors.Is(err, errRejected) {
+			t.Errorf("error must be ErrSendMoneyNotConfirmed and the error of the confirmer: %v", err)
+		}
+
+		if called.Load() != 0 {
+			t.Errorf("the server was called %d times", called.Load())
+		}
+	})
+
+	t.Run("SendMoney validates the input before the confirmation", func(t *testing.T) {
+		client, err := NewClient(
+			WithBaseURL("https://example.com"),
+			WithCredentials("api_key", "api_secret"),
+			WithSendMoneyConfirmer(func(context.Context, SendMoneyInput) error {
+				t.Error("the confirmer must not be called")
+				return nil
+			}),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tests := []struct {
+			name  string
+			input SendMoneyInput
+		}{
+			{name: "no currency", input: SendMoneyInput{Address: "1v6zFvyNPgdRvhUufkRoTtgyiw1xigncc", Amount: MustParseDecimal("1")}},
+			{name: "jpy", input: SendMoneyInput{Address: "1v6zFvyNPgdRvhUufkRoTtgyiw1xigncc", Amount: MustParseDecimal("1"), Currency: CurrencyJPY}},
+			{name: "zero amount", input: SendMoneyInput{Address: "1v6zFvyNPgdRvhUufkRoTtgyiw1xigncc", Currency: CurrencyBTC}},
+			{name: "eth address for btc", input: SendMoneyInput{Address: "0x52908400098527886E0F7030069857D2E4169EE7", Amount: MustParseDecimal("1"), Currency: CurrencyBTC}},
+			{name: "btc address for eth", input: SendMoneyInput{Address: "1v6zFvyNPgdRvhUufkRoTtgyiw1xigncc", Amount: MustParseDecimal("1"), Currency: CurrencyETH}},
+			{name: "empty address", input: SendMoneyInput{Amount: MustParseDecimal("1"), Currency: "xrp"}},
+		}
+		for _, tt := range tests {
+			if _, err := client.SendMoney(context.Background(), tt.input); !errors.Is(err, ErrInvalidSendMoney) {
+				t.Errorf("%s: error is not ErrInvalidSendMoney: %v", tt.name, err)
+			}
+		}
+	})
+}
+
+func Test_validAddress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		currency Currency
+		address  string
+		want     bool
+	}{
+		{currency: CurrencyBTC, address: "1v6zFvyNPgdRvhUufkRoTtgyiw1xigncc", want: true},
+		{currency: CurrencyBTC, address: "3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", want: true},
+		{currency: CurrencyBTC, address: "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq", want: true},
+		{currency: CurrencyBTC, address: "BC1QAR0SRRR7XFKVY5L643LYDNW9RE59GTZZWF5MDQ", want: true},
+		{currency: CurrencyBTC, address: "bc1qAR0SRRR7XFKVY5L643LYDNW9RE59GTZZWF5MDQ", want: false},
+		{currency: CurrencyBTC, address: "1v6zFvyNPgdRvhUufkRoTtgyiw1xigncO", want: false},
+		{currency: CurrencyETH, address: "0x52908400098527886E0F7030069857D2E4169EE7", want: true},
+		{currency: CurrencyETH, address: "52908400098527886E0F7030069857D2E4169EE7", want: false},
+		{currency: CurrencyLsk, address: "lskdxc4ta5j43jp9ro3f8zqbxta9fn6jwzjucw7yt", want: true},
+		{currency: CurrencyLsk, address: "12345678901234567890L", want: true},
+		{currency: CurrencyMona, address: "MLM5wyMxEj4ZrPAUyCVhtkpThTJWwNu5oK", want: true},
+		{currency: CurrencyMona, address: "mona1q4kpn6psthgd5ur894auhjj2g02wlgmp8ke08ne", want: true},
+		{currency: CurrencyMona, address: "MONA1Q4KPN6PSTHGD5UR894AUHJJ2G02WLGMP8KE08NE", want: true},
+		{currency: "xrp", address: "rLW9gnQo7BQhU6igk5keqYnH3TVrCxGRzm", want: true},
+		{currency: "xrp", address: "rLW9gnQo7BQh U6igk", want: false},
+	}
+	for _, tt := range tests {
+		if got := validAddress(tt.currency, tt.address); got != tt.want {
+			t.Errorf("validAddress(%s, %q) = %v, want %v", tt.currency, tt.address, got, tt.want)
+		}
+	}
+}
+
+func TestClient_GetSendMoneyHistory(t *testing.T) {
+	t.Run("GetSendMoneyHistory returns the transfers of the currency", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			wantMethod := http.MethodGet
+			if diff := cmp.Diff(wantMethod, r.Method); diff != "" {
+				printDiff(t, diff)
+			}
+
+			wantEndpoint := "/api/send_money"
+			if diff := cmp.Diff(wantEndpoint, r.URL.Path); diff != "" {
+				printDiff(t, diff)
+			}
+
+			wantQuery := "currency=BTC&limit=2"
+			if diff := cmp.Diff(wantQuery, r.URL.RawQuery); diff != "" {
+				printDiff(t, diff)
+			}
+
+			w.Write([]byte(`{
+				"success": true,
+				"sends": [
+					{
+						"id": 2,
+						"amount": "0.05",
+						"currency": "BTC",
+						"fee": "0.0",
+						"address": "13PhzoK8me3u5nHzzFD85qT9RqEWR9M4Ty",
+						"status": "finished",
+						"created_at": "2015-06-13T08:25:20.000Z"
+					}
+				]
+			}`)) //nolint: errcheck // ignore error
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := client.GetSendMoneyHistory(context.Background(), GetSendMoneyHistoryInput{
+			Currency:   CurrencyBTC,
+			Pagination: Pagination{Limit: 2},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(got.Sends) != 1 {
+			t.Fatalf("got %d sends, want 1", len(got.Sends))
+		}
+		send := got.Sends[0]
+		if send.ID != 2 || send.Currency != CurrencyBTC || send.Status != SendMoneyStatusFinished ||
+			!send.Amount.Equal(MustParseDecimal("0.05")) || !send.Fee.IsZero() ||
+			send.Address != "13PhzoK8me3u5nHzzFD85qT9RqEWR9M4Ty" || send.CreatedAt.IsZero() {
+			t.Errorf("unexpected send: %+v", send)
+		}
+	})
+
+	t.Run("GetSendMoneyHistory returns an error if currency is empty", func(t *testing.T) {
+		client, err := NewClient(WithCredentials("api_key", "api_secret"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := client.GetSendMoneyHistory(context.Background(), GetSendMoneyHistoryInput{}); !errors.Is(err, ErrInvalidSendMoney) {
+			t.Errorf("error is not ErrInvalidSendMoney: %v", err)
+		}
+	})
+}
@@ -0,0 +1,328 @@
+package coincheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// defaultStreamPingInterval is the default interval of the ping messages.
+	defaultStreamPingInterval = 15 * time.Second
+	// defaultStreamMaxStall is the default maximum time without any message from the server.
+	defaultStreamMaxStall = 60 * time.Second
+	// defaultStreamMinBackoff is the default delay before the first reconnection.
+	defaultStreamMinBackoff = time.Second
+	// defaultStreamMaxBackoff is the default maximum delay between reconnections.
+	defaultStreamMaxBackoff = 30 * time.Second
+	// streamWriteTimeout is the maximum time to write a control message.
+	streamWriteTimeout = 10 * time.Second
+)
+
+// StreamState represents the connection state of the streaming clients.
+type StreamState string
+
+// String returns the string representation of the StreamState.
+func (s StreamState) String() string {
+	return string(s)
+}
+
+const (
+	// StreamStateConnected means the stream connected and subscribed to all channels.
+	StreamStateConnected StreamState = "connected"
+	// StreamStateReconnecting means the connection was lost (or could not be established)
+	// and the stream is waiting to reconnect.
+	StreamStateReconnecting StreamState = "reconnecting"
+	// StreamStateGapDetected means the stream reconnected after the connection was lost.
+	// The events between StreamStateEvent.Since and now were not delivered.
+	// e.g. Use BackfillTrades or GetOrderBooks to fill the gap.
+	StreamStateGapDetected StreamState = "gap_detected"
+	// StreamStateDisconnected means Run returned.
+	StreamStateDisconnected StreamState = "disconnected"
+)
+
+// StreamStateEvent represents a change of the connection state of the streaming clients.
+type StreamStateEvent struct {
+	// State is the new state.
+	State StreamState
+	// Attempt is the number of the consecutive reconnections. It's set when State is StreamStateReconnecting.
+	Attempt int
+	// Delay is the delay before the next reconnection. It's set when State is StreamStateReconnecting.
+	Delay time.Duration
+	// Since is the time when the connection was lost. It's set when State is StreamStateGapDetected.
+	Since time.Time
+	// Err is the reason of the disconnection. It's nil if the context was cancelled.
+	Err error
+}
+
+// StreamConfig configures the reconnection and the liveness detection of the streaming clients.
+// The zero value is a valid configuration that reconnects forever.
+type StreamConfig struct {
+	// OnStateChange is called when the connection state changes. It may be nil.
+	OnStateChange func(StreamStateEvent)
+	// PingInterval is the interval of the ping messages. If it's 0, 15 seconds is used.
+	PingInterval time.Duration
+	// MaxStall is the maximum time without any message (including pong) from the server.
+	// If the server is silent for longer than MaxStall, the stream reconnects.
+	// If it's 0, 60 seconds is used.
+	MaxStall time.Duration
+	// MinBackoff is the delay before the first reconnection. If it's 0, 1 second is used.
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum delay between reconnections. If it's 0, 30 seconds is used.
+	// The delay doubles on each failure up to MaxBackoff, and a random jitter is applied.
+	MaxBackoff time.Duration
+	// MaxRetries is the maximum number of the consecutive reconnections. If it's 0, the stream retries forever.
+	MaxRetries int
+	// DisableReconnect disables the reconnection. Run returns when the connection is lost.
+	DisableReconnect bool
+}
+
+// withDefaults returns the config with the default values for the zero fields.
+func (c StreamConfig) withDefaults() StreamConfig {
+	if c.PingInterval <= 0 {
+		c.PingInterval = defaultStreamPingInterval
+	}
+	if c.MaxStall <= 0 {
+		c.MaxStall = defaultStreamMaxStall
+	}
+	if c.MinBackoff <= 0 {
+		c.MinBackoff = defaultStreamMinBackoff
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = defaultStreamMaxBackoff
+	}
+	if c.MaxBackoff < c.MinBackoff {
+		c.MaxBackoff = c.MinBackoff
+	}
+	return c
+}
+
+// backoff returns the delay before the attempt-th reconnection.
+// It's MinBackoff * 2^(attempt-1) capped at MaxBackoff, with a random jitter between 50% and 100%.
+func (c StreamConfig) backoff(attempt int) time.Duration {
+	delay := c.MaxBackoff
+	if attempt < 32 {
+		if d := c.MinBackoff << (attempt - 1); d > 0 && d < c.MaxBackoff {
+			delay = d
+		}
+	}
+	half := int64(delay / 2)
+	return time.Duration(half + rand.Int63n(half+1)) //nolint: gosec // jitter does not need a secure random number
+}
+
+// subscribeMessage is the message to subscribe to a channel of the WebSocket API.
+// e.g. {"type":"subscribe","channel":"btc_jpy-trades"}
+type subscribeMessage struct {
+	Type    string `json:"type"`
+	Channel string `json:"channel"`
+}
+
+// stream is a connection to the Coincheck WebSocket API. It's shared by the streaming clients.
+// It remembers the subscribed channels and subscribes to them again when it reconnects.
+type stream struct {
+	// url is the URL of the WebSocket API.
+	url string
+	// dialer is the WebSocket dialer.
+	dialer *websocket.Dialer
+	// config is the reconnection and liveness detection config.
+	config StreamConfig
+	// handle is called with each message received from the server.
+	handle func(message []byte) error
+	// onError is called with the error returned by handle. It may be nil.
+	onError func(error)
+	// authenticate is called after connecting and before subscribing. It may be nil.
+	authenticate func(conn *websocket.Conn) error
+	// newSubscribeMessage returns the message to subscribe to the channel.
+	// If it's nil, subscribeMessage is used.
+	newSubscribeMessage func(channel string) any
+
+	// mu protects conn and channels, and serializes writes to conn.
+	mu sync.Mutex
+	// conn is the current connection. It's nil if the stream is not connected.
+	conn *websocket.Conn
+	// channels is the subscribed channels in the order of subscription.
+	channels []string
+}
+
+// subscribe subscribes to the channel. If the stream is connected, the subscribe message is sent immediately.
+// Otherwise, it's sent when the stream connects.
+func (s *stream) subscribe(channel string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, c := range s.channels {
+		if c == channel {
+			return nil
+		}
+	}
+	s.channels = append(s.channels, channel)
+
+	if s.conn == nil {
+		return nil
+	}
+	return s.writeJSON(s.subscribeMessage(channel))
+}
+
+// subscribeMessage returns the message to subscribe to the channel.
+func (s *stream) subscribeMessage(channel string) any {
+	if s.newSubscribeMessage != nil {
+		return s.newSubscribeMessage(channel)
+	}
+	return subscribeMessage{Type: "subscribe", Channel: channel}
+}
+
+// writeJSON sends v as a JSON message. s.mu must be held.
+func (s *stream) writeJSON(v any) error {
+	if err := s.conn.WriteJSON(v); err != nil {
+		return withPrefixError(err)
+	}
+	return nil
+}
+
+// notify calls OnStateChange if it's set.
+func (s *stream) notify(event StreamStateEvent) {
+	if s.config.OnStateChange != nil {
+		s.config.OnStateChange(event)
+	}
+}
+
+// isFatalStreamError returns true if reconnecting does not fix the error.
+func isFatalStreamError(err error) bool {
+	return errors.Is(err, ErrUnauthorized) || errors.Is(err, ErrNoCredentials) || errors.Is(err, ErrNonceSource)
+}
+
+// run connects to the WebSocket API and reads messages until the context is cancelled.
+// When the connection is lost, it reconnects with exponential backoff and subscribes to all channels again.
+// It returns ctx.Err() if the context is cancelled, or the last error if it gives up reconnecting.
+func (s *stream) run(ctx context.Context) error {
+	s.config = s.config.withDefaults()
+
+	var (
+		attempt int
+		// lostAt is the time when the last established connection was lost.
+		lostAt time.Time
+	)
+	for {
+		connected, err := s.runOnce(ctx, func() {
+			attempt = 0
+			s.notify(StreamStateEvent{State: StreamStateConnected})
+			if !lostAt.IsZero() {
+				s.notify(StreamStateEvent{State: StreamStateGapDetected, Since: lostAt})
+				lostAt = time.Time{}
+			}
+		})
+		if connected {
+			lostAt = time.Now()
+		}
+
+		if ctx.Err() != nil {
+			s.notify(StreamStateEvent{State: StreamStateDisconnected})
+			return ctx.Err()
+		}
+		attempt++
+		if s.config.DisableReconnect || isFatalStreamError(err) ||
+			(s.config.MaxRetries > 0 && attempt > s.config.MaxRetries) {
+			s.notify(StreamStateEvent{State: StreamStateDisconnected, Err: err})
+			return err
+		}
+
+		delay := s.config.backoff(attempt)
+		s.notify(StreamStateEvent{State: StreamStateReconnecting, Attempt: attempt, Delay: delay, Err: err})
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			s.notify(StreamStateEvent{State: StreamStateDisconnected})
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// runOnce connects to the WebSocket API, subscribes to the channels, and reads messages until
+// the context is cancelled or the connection is lost. onConnected is called after subscribing.
+// It returns true if the connection was established.
+func (s *stream) runOnce(ctx context.Context, onConnected func()) (bool, error) {
+	conn, _, err := s.dialer.DialContext(ctx, s.url, nil)
+	if err != nil {
+		return false, withPrefixError(err)
+	}
+	defer conn.Close() //nolint: errcheck // ignore error
+
+	// Unblock ReadMessage when the context is cancelled, and send ping messages for the liveness detection.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(s.config.PingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				conn.Close() //nolint: errcheck // it unblocks ReadMessage
+				return
+			case <-done:
+				return
+			case <-ticker.C:
+				// If the ping can not be sent, ReadMessage fails by the read deadline.
+				conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(streamWriteTimeout)) //nolint: errcheck // see above
+			}
+		}
+	}()
+
+	if s.authenticate != nil {
+		if err := s.authenticate(conn); err != nil {
+			return false, err
+		}
+	}
+
+	s.mu.Lock()
+	s.conn = conn
+	for _, channel := range s.channels {
+		if err := s.writeJSON(s.subscribeMessage(channel)); err != nil {
+			s.conn = nil
+			s.mu.Unlock()
+			return false, err
+		}
+	}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		s.conn = nil
+		s.mu.Unlock()
+	}()
+	onConnected()
+
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(s.config.MaxStall))
+	})
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(s.config.MaxStall)); err != nil {
+			return true, withPrefixError(err)
+		}
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return true, withPrefixError(err)
+		}
+		if err := s.handle(message); err != nil && s.onError != nil {
+			s.onError(err)
+		}
+	}
+}
+
+// unmarshalStreamInt decodes an integer that the WebSocket API sends as a JSON string or a JSON number.
+// An empty string is decoded as 0.
+func unmarshalStreamInt(raw json.RawMessage) (int, error) {
+	s := strings.Trim(string(raw), `"`)
+	if s == "" || s == "null" {
+		return 0, nil
+	}
+	return strconv.Atoi(s)
+}
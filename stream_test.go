@@ -0,0 +1,299 @@
+package coincheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/websocket"
+)
+
+// newWebSocketServer returns a test server that upgrades every request to WebSocket
+// and calls handler with the connection.
+func newWebSocketServer(t *testing.T, handler func(conn *websocket.Conn)) *httptest.Server {
+	t.Helper()
+
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close() //nolint: errcheck // ignore error
+		handler(conn)
+	}))
+}
+
+// webSocketURL returns the ws:// URL of the test server.
+func webSocketURL(s *httptest.Server) string {
+	return "ws" + strings.TrimPrefix(s.URL, "http")
+}
+
+// readSubscribe reads a subscribe message and returns the channel.
+func readSubscribe(t *testing.T, conn *websocket.Conn) string {
+	t.Helper()
+
+	var msg subscribeMessage
+	if err := conn.ReadJSON(&msg); err != nil {
+		t.Error(err)
+		return ""
+	}
+	if msg.Type != "subscribe" {
+		t.Errorf("type: got %v, want subscribe", msg.Type)
+	}
+	return msg.Channel
+}
+
+func TestStream(t *testing.T) {
+	t.Run("stream subscribes to the channels on connect and while connected", func(t *testing.T) {
+		got := make(chan string, 3)
+		testServer := newWebSocketServer(t, func(conn *websocket.Conn) {
+			got <- readSubscribe(t, conn)
+			got <- readSubscribe(t, conn)
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(`"subscribed"`)); err != nil {
+				t.Error(err)
+			}
+			got <- readSubscribe(t, conn)
+			conn.ReadMessage() //nolint: errcheck // wait for the client to close
+		})
+		defer testServer.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var s *stream
+		s = &stream{
+			url:    webSocketURL(testServer),
+			dialer: websocket.DefaultDialer,
+			handle: func(message []byte) error {
+				return s.subscribe("btc_jpy-orderbook")
+			},
+		}
+		if err := s.subscribe("btc_jpy-trades"); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.subscribe("btc_jpy-trades"); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.subscribe("etc_jpy-trades"); err != nil {
+			t.Fatal(err)
+		}
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- s.run(ctx)
+		}()
+
+		want := []string{"btc_jpy-trades", "etc_jpy-trades", "btc_jpy-orderbook"}
+		for _, w := range want {
+			if diff := cmp.Diff(w, <-got); diff != "" {
+				printDiff(t, diff)
+			}
+		}
+
+		cancel()
+		if err := <-errCh; !errors.Is(err, context.Canceled) {
+			t.Errorf("error is not context.Canceled: %v", err)
+		}
+	})
+
+	t.Run("stream gives up after MaxRetries if it can not connect", func(t *testing.T) {
+		var states []StreamStateEvent
+		s := &stream{
+			url:    "ws://127.0.0.1:0/",
+			dialer: websocket.DefaultDialer,
+			config: StreamConfig{
+				OnStateChange: func(e StreamStateEvent) {
+					states = append(states, e)
+				},
+				MinBackoff: time.Millisecond,
+				MaxRetries: 2,
+			},
+			handle: func(message []byte) error { return nil },
+		}
+		if err := s.run(context.Background()); err == nil {
+			t.Error("want error, but got nil")
+		}
+
+		var got []StreamState
+		for _, e := range states {
+			got = append(got, e.State)
+			if e.Err == nil {
+				t.Errorf("%s: error must be set", e.State)
+			}
+		}
+		want := []StreamState{StreamStateReconnecting, StreamStateReconnecting, StreamStateDisconnected}
+		if diff := cmp.Diff(want, got); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("stream reconnects and subscribes again when the connection is lost", func(t *testing.T) {
+		subscribed := make(chan string, 4)
+		var connections atomic.Int32
+		testServer := newWebSocketServer(t, func(conn *websocket.Conn) {
+			subscribed <- readSubscribe(t, conn)
+			if connections.Add(1) == 1 {
+				return // drop the first connection
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(`"hello"`)); err != nil {
+				t.Error(err)
+			}
+			conn.ReadMessage() //nolint: errcheck // wait for the client to close
+		})
+		defer testServer.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var states []StreamStateEvent
+		s := &stream{
+			url:    webSocketURL(testServer),
+			dialer: websocket.DefaultDialer,
+			config: StreamConfig{
+				OnStateChange: func(e StreamStateEvent) {
+					states = append(states, e)
+				},
+				MinBackoff: time.Millisecond,
+			},
+			handle: func(message []byte) error {
+				cancel()
+				return nil
+			},
+		}
+		if err := s.subscribe("btc_jpy-trades"); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := s.run(ctx); !errors.Is(err, context.Canceled) {
+			t.Fatalf("error is not context.Canceled: %v", err)
+		}
+
+		for i := 0; i < 2; i++ {
+			if diff := cmp.Diff("btc_jpy-trades", <-subscribed); diff != "" {
+				printDiff(t, diff)
+			}
+		}
+
+		var got []StreamState
+		for _, e := range states {
+			got = append(got, e.State)
+		}
+		want := []StreamState{
+			StreamStateConnected,
+			StreamStateReconnecting,
+			StreamStateConnected,
+			StreamStateGapDetected,
+			StreamStateDisconnected,
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			printDiff(t, diff)
+		}
+		if states[3].Since.IsZero() {
+			t.Error("Since of the gap must be set")
+		}
+	})
+
+	t.Run("stream reconnects if the server stops responding", func(t *testing.T) {
+		var connections atomic.Int32
+		testServer := newWebSocketServer(t, func(conn *websocket.Conn) {
+			if connections.Add(1) == 1 {
+				// Do not read, so that the ping is never answered.
+				time.Sleep(500 * time.Millisecond)
+				return
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(`"hello"`)); err != nil {
+				t.Error(err)
+			}
+			conn.ReadMessage() //nolint: errcheck // wait for the client to close
+		})
+		defer testServer.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		var states []StreamState
+		s := &stream{
+			url:    webSocketURL(testServer),
+			dialer: websocket.DefaultDialer,
+			config: StreamConfig{
+				OnStateChange: func(e StreamStateEvent) {
+					states = append(states, e.State)
+				},
+				PingInterval: 10 * time.Millisecond,
+				MaxStall:     50 * time.Millisecond,
+				MinBackoff:   time.Millisecond,
+			},
+			handle: func(message []byte) error {
+				cancel()
+				return nil
+			},
+		}
+		if err := s.run(ctx); !errors.Is(err, context.Canceled) {
+			t.Fatalf("error is not context.Canceled: %v", err)
+		}
+		if diff := cmp.Diff(StreamStateReconnecting, states[1]); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+}
+
+func TestStreamConfig_backoff(t *testing.T) {
+	t.Parallel()
+
+	config := StreamConfig{MinBackoff: time.Second, MaxBackoff: 10 * time.Second}.withDefaults()
+	tests := []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{attempt: 1, max: time.Second},
+		{attempt: 2, max: 2 * time.Second},
+		{attempt: 3, max: 4 * time.Second},
+		{attempt: 4, max: 8 * time.Second},
+		{attempt: 5, max: 10 * time.Second},
+		{attempt: 100, max: 10 * time.Second},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 10; i++ {
+			got := config.backoff(tt.attempt)
+			if got < tt.max/2 || got > tt.max {
+				t.Errorf("attempt %d: got %v, want between %v and %v", tt.attempt, got, tt.max/2, tt.max)
+			}
+		}
+	}
+}
+
+func TestUnmarshalStreamInt(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want int
+	}{
+		{in: `"2357062"`, want: 2357062},
+		{in: `2357062`, want: 2357062},
+		{in: `""`, want: 0},
+		{in: `null`, want: 0},
+	}
+	for _, tt := range tests {
+		got, err := unmarshalStreamInt(json.RawMessage(tt.in))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			printDiff(t, diff)
+		}
+	}
+
+	if _, err := unmarshalStreamInt(json.RawMessage(`"abc"`)); err == nil {
+		t.Error("want error, but got nil")
+	}
+}
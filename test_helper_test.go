@@ -1,9 +1,16 @@
 package coincheck
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
 
 // printDiff prints the gocmp diff.
 func printDiff(t *testing.T, diff string) {
 	t.Helper()
 	t.Errorf("differs: (-want +got)\n%s", diff)
 }
+
+// equateTime is the gocmp option to compare Time by the time instant.
+var equateTime = cmp.Comparer(func(a, b Time) bool { return a.EqualTime(b) })
@@ -43,19 +43,19 @@ type GetTickerInput struct {
 // GetTickerResponse represents the output from GetTicker.
 type GetTickerResponse struct {
 	// Last is latest quote.
-	Last float64 `json:"last"`
+	Last Decimal `json:"last"`
 	// Bid is current highest buying order.
-	Bid float64 `json:"bid"`
+	Bid Decimal `json:"bid"`
 	// Ask is current lowest selling order.
-	Ask float64 `json:"ask"`
+	Ask Decimal `json:"ask"`
 	// High is highest price in last 24 hours.
-	High float64 `json:"high"`
+	High Decimal `json:"high"`
 	// Low is lowest price in last 24 hours.
-	Low float64 `json:"low"`
+	Low Decimal `json:"low"`
 	// Volume is trading Volume in last 24 hours.
-	Volume float64 `json:"volume"`
-	// Timestamp is current time. It's Unix Timestamp.
-	Timestamp float64 `json:"timestamp"`
+	Volume Decimal `json:"volume"`
+	// Timestamp is current time. It's Unix Timestamp in the response.
+	Timestamp Time `json:"timestamp"`
 }
 
 // GetTicker check latest ticker information.
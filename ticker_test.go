@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -30,13 +31,13 @@ func TestClientGetTicker(t *testing.T) {
 			}
 
 			result := GetTickerResponse{
-				Last:      1000000,
-				Bid:       999000,
-				Ask:       1001000,
-				High:      1002000,
-				Low:       998000,
-				Volume:    100,
-				Timestamp: 1609459200,
+				Last:      MustParseDecimal("1000000"),
+				Bid:       MustParseDecimal("999000"),
+				Ask:       MustParseDecimal("1001000"),
+				High:      MustParseDecimal("1002000"),
+				Low:       MustParseDecimal("998000"),
+				Volume:    MustParseDecimal("100"),
+				Timestamp: NewTime(time.Unix(1609459200, 0)),
 			}
 			if err := json.NewEncoder(w).Encode(result); err != nil {
 				t.Fatal(err)
@@ -61,15 +62,15 @@ func TestClientGetTicker(t *testing.T) {
 
 		// Check the result
 		want := &GetTickerResponse{
-			Last:      1000000,
-			Bid:       999000,
-			Ask:       1001000,
-			High:      1002000,
-			Low:       998000,
-			Volume:    100,
-			Timestamp: 1609459200,
+			Last:      MustParseDecimal("1000000"),
+			Bid:       MustParseDecimal("999000"),
+			Ask:       MustParseDecimal("1001000"),
+			High:      MustParseDecimal("1002000"),
+			Low:       MustParseDecimal("998000"),
+			Volume:    MustParseDecimal("100"),
+			Timestamp: NewTime(time.Unix(1609459200, 0)),
 		}
-		if diff := cmp.Diff(want, got); diff != "" {
+		if diff := cmp.Diff(want, got, equateTime); diff != "" {
 			printDiff(t, diff)
 		}
 	})
@@ -0,0 +1,113 @@
+package coincheck
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Time represents a timestamp of the Coincheck API.
+//
+// The Coincheck API uses several representations for timestamps:
+//   - RFC 3339 string with fractional seconds and timezone offset (e.g. "2015-01-10T05:55:38.000Z")
+//   - Unix seconds as a number with fractional seconds (e.g. 1722661800.123)
+//   - Unix seconds as a string (e.g. "1659321701")
+//
+// Time can be decoded from all of them, and it keeps the original representation,
+// so encoding a decoded Time produces the same JSON.
+type Time struct {
+	time.Time
+	// raw is the original JSON representation. It's empty if Time is not decoded from JSON.
+	raw string
+}
+
+// NewTime returns a new Time. It's encoded to JSON as an RFC 3339 string.
+func NewTime(t time.Time) Time {
+	return Time{Time: t}
+}
+
+// EqualTime reports whether t and u represent the same time instant.
+// The original representation is not compared. To compare with a time.Time, use Equal.
+func (t Time) EqualTime(u Time) bool {
+	return t.Time.Equal(u.Time)
+}
+
+// Raw returns the original JSON representation. It's empty if Time is not decoded from JSON.
+func (t Time) Raw() string {
+	return t.raw
+}
+
+// UnmarshalJSON decodes the timestamp from JSON.
+func (t *Time) UnmarshalJSON(b []byte) error {
+	raw := string(bytes.TrimSpace(b))
+	if raw == "null" || raw == `""` {
+		*t = Time{raw: raw}
+		return nil
+	}
+
+	s := raw
+	if strings.HasPrefix(raw, `"`) {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return withPrefixError(err)
+		}
+	}
+
+	parsed, err := parseTime(s)
+	if err != nil {
+		return withPrefixError(err)
+	}
+	*t = Time{Time: parsed, raw: raw}
+	return nil
+}
+
+// UnmarshalText decodes the timestamp from text, e.g. a query parameter or a map key.
+// It accepts the same representations as UnmarshalJSON and keeps the original representation.
+func (t *Time) UnmarshalText(text []byte) error {
+	return t.UnmarshalJSON([]byte(strconv.Quote(string(text))))
+}
+
+// MarshalJSON encodes the timestamp to JSON. If Time is decoded from JSON,
+// it returns the original representation. Otherwise, it returns an RFC 3339 string.
+// The zero Time is encoded as null.
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.raw != "" {
+		return []byte(t.raw), nil
+	}
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(strconv.Quote(t.Format(time.RFC3339Nano))), nil
+}
+
+// parseTime parses an RFC 3339 string or Unix seconds.
+func parseTime(s string) (time.Time, error) {
+	if strings.ContainsAny(s, "-:T") {
+		return time.Parse(time.RFC3339Nano, s)
+	}
+	return parseUnixSeconds(s)
+}
+
+// parseUnixSeconds parses Unix seconds with fractional seconds (e.g. "1722661800.123").
+// It does not use float64, so the fractional seconds are exact up to nanoseconds.
+func parseUnixSeconds(s string) (time.Time, error) {
+	sec, frac, _ := strings.Cut(s, ".")
+	seconds, err := strconv.ParseInt(sec, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid unix time %q: %w", s, err)
+	}
+
+	var nanos int64
+	if frac != "" {
+		if len(frac) > 9 {
+			frac = frac[:9]
+		}
+		frac += strings.Repeat("0", 9-len(frac))
+		if nanos, err = strconv.ParseInt(frac, 10, 64); err != nil {
+			return time.Time{}, fmt.Errorf("invalid unix time %q: %w", s, err)
+		}
+	}
+	return time.Unix(seconds, nanos).UTC(), nil
+}
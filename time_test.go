@@ -0,0 +1,121 @@
+package coincheck
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTime(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Time is decoded from every representation of the Coincheck API", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name string
+			json string
+			want time.Time
+		}{
+			{
+				name: "RFC 3339 with milliseconds",
+				json: `"2015-01-10T05:55:38.000Z"`,
+				want: time.Date(2015, 1, 10, 5, 55, 38, 0, time.UTC),
+			},
+			{
+				name: "RFC 3339 with timezone offset",
+				json: `"2024-08-03T14:10:00.123+09:00"`,
+				want: time.Date(2024, 8, 3, 5, 10, 0, 123000000, time.UTC),
+			},
+			{
+				name: "Unix seconds as a number",
+				json: `1722661800`,
+				want: time.Date(2024, 8, 3, 5, 10, 0, 0, time.UTC),
+			},
+			{
+				name: "Unix seconds as a number with fractional seconds",
+				json: `1722661800.123456789`,
+				want: time.Date(2024, 8, 3, 5, 10, 0, 123456789, time.UTC),
+			},
+			{
+				name: "Unix seconds as a string",
+				json: `"1722661800.5"`,
+				want: time.Date(2024, 8, 3, 5, 10, 0, 500000000, time.UTC),
+			},
+			{
+				name: "null",
+				json: `null`,
+				want: time.Time{},
+			},
+		}
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var got Time
+				if err := json.Unmarshal([]byte(tt.json), &got); err != nil {
+					t.Fatal(err)
+				}
+				if !got.Time.Equal(tt.want) {
+					t.Errorf("got %v, want %v", got.Time, tt.want)
+				}
+
+				// The original representation is preserved.
+				b, err := json.Marshal(got)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(tt.json, string(b)); diff != "" {
+					printDiff(t, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Time returns an error for an invalid timestamp", func(t *testing.T) {
+		t.Parallel()
+
+		var got Time
+		if err := json.Unmarshal([]byte(`"yesterday"`), &got); err == nil {
+			t.Error("want error, but got nil")
+		}
+	})
+
+	t.Run("NewTime is encoded as an RFC 3339 string", func(t *testing.T) {
+		t.Parallel()
+
+		b, err := json.Marshal(NewTime(time.Date(2021, 1, 1, 0, 0, 0, 500, time.UTC)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(`"2021-01-01T00:00:00.0000005Z"`, string(b)); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("Time is decoded from text in the same way as JSON", func(t *testing.T) {
+		t.Parallel()
+
+		var got Time
+		if err := got.UnmarshalText([]byte("1659321701")); err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(time.Unix(1659321701, 0)) {
+			t.Errorf("got %v", got.Time)
+		}
+
+		var fromJSON Time
+		if err := json.Unmarshal([]byte(`"1659321701"`), &fromJSON); err != nil {
+			t.Fatal(err)
+		}
+		if !got.EqualTime(fromJSON) {
+			t.Errorf("got %v, want %v", got.Time, fromJSON.Time)
+		}
+		if diff := cmp.Diff(fromJSON.Raw(), got.Raw()); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+}
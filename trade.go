@@ -3,12 +3,16 @@ package coincheck
 import (
 	"context"
 	"net/http"
+	"sort"
+	"time"
 )
 
 // GetTradesInput represents the input parameter for GetTrades
 type GetTradesInput struct {
 	// Pair is the pair of the currency. e.g. btc_jpy.
 	Pair Pair
+	// Pagination is the pagination of the data. If you don't set it, the newest page is returned.
+	Pagination Pagination
 }
 
 // GetTradesResponse represents the output from GetTrades
@@ -26,27 +30,29 @@ type Trade struct {
 	// ID is the trade ID.
 	ID int `json:"id"`
 	// Amount is the amount of the trade.
-	Amount float64 `json:"amount"`
+	Amount Decimal `json:"amount"`
 	// Rate is the rate of the trade.
-	Rate float64 `json:"rate"`
+	Rate Decimal `json:"rate"`
 	// Pair is the pair of the currency.
 	Pair Pair `json:"pair"`
 	// OrderType is the order type.
 	OrderType OrderType `json:"order_type"`
 	// CreatedAt is the creation time of the trade.
-	CreatedAt string `json:"created_at"`
+	CreatedAt Time `json:"created_at"`
 }
 
 // GetTrades returns a list of trades (order transactions).
 // API: GET /api/trades
 // Visibility: Public
+// If you want to go back in time, use BackfillTrades.
 func (c *Client) GetTrades(ctx context.Context, input GetTradesInput) (*GetTradesResponse, error) {
+	queryParam := input.Pagination.queryParam()
+	queryParam["pair"] = string(input.Pair)
+
 	req, err := c.createRequest(ctx, createRequestInput{
-		method: http.MethodGet,
-		path:   "/api/trades",
-		queryParam: map[string]string{
-			"pair": string(input.Pair),
-		},
+		method:     http.MethodGet,
+		path:       "/api/trades",
+		queryParam: queryParam,
 	})
 	if err != nil {
 		return nil, err
@@ -58,3 +64,81 @@ func (c *Client) GetTrades(ctx context.Context, input GetTradesInput) (*GetTrade
 	}
 	return &output, nil
 }
+
+// defaultBackfillTradesLimit is the default page size of BackfillTrades.
+const defaultBackfillTradesLimit = 100
+
+// BackfillTradesInput represents the input parameter for the BackfillTrades method.
+type BackfillTradesInput struct {
+	// Pair is the pair of the currency. e.g. btc_jpy.
+	Pair Pair
+	// EndingBefore is the trade ID to start from. Trades older than it are returned.
+	// If it's 0, BackfillTrades starts from the newest trade.
+	EndingBefore int
+	// Since is the time bound. BackfillTrades stops when it reaches a trade created before Since.
+	// If it's zero, BackfillTrades walks until there is no more trade.
+	Since time.Time
+	// Limit is the page size. If it's 0 or less, 100 is used.
+	Limit int
+	// MaxTrades is the maximum number of trades passed to fn. Duplicated trades are not counted.
+	// If it's 0 or less, there is no limit.
+	MaxTrades int
+}
+
+// BackfillTrades walks the trades of the pair backwards in time and calls fn for each trade,
+// from the newest to the oldest. Duplicated trades across pages are skipped by Trade.ID.
+// If fn returns an error, BackfillTrades stops and returns it.
+// Visibility: Public
+func (c *Client) BackfillTrades(ctx context.Context, input BackfillTradesInput, fn func(Trade) error) error {
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultBackfillTradesLimit
+	}
+
+	fetch := func(ctx context.Context, pagination Pagination) ([]Trade, error) {
+		resp, err := c.GetTrades(ctx, GetTradesInput{Pair: input.Pair, Pagination: pagination})
+		if err != nil {
+			return nil, err
+		}
+		return resp.Data, nil
+	}
+	p := NewPaginator(fetch, func(t Trade) int { return t.ID }, PaginatorInput{
+		Pagination: Pagination{
+			Limit:           limit,
+			PaginationOrder: PaginationOrderDesc,
+			EndingBefore:    input.EndingBefore,
+		},
+	})
+
+	// lastID is the ID of the last trade passed to fn. Trades are walked in descending order,
+	// so a trade whose ID is not less than lastID is a duplicate.
+	lastID := input.EndingBefore
+	// delivered is the number of trades passed to fn. It's counted here instead of PaginatorInput.MaxItems,
+	// because MaxItems also counts the duplicated trades that are skipped.
+	delivered := 0
+	for p.HasNext() {
+		page, err := p.Next(ctx)
+		if err != nil {
+			return err
+		}
+		sort.SliceStable(page, func(i, j int) bool { return page[i].ID > page[j].ID })
+
+		for _, trade := range page {
+			if lastID > 0 && trade.ID >= lastID {
+				continue
+			}
+			if !input.Since.IsZero() && trade.CreatedAt.Before(input.Since) {
+				return nil
+			}
+			if err := fn(trade); err != nil {
+				return err
+			}
+			lastID = trade.ID
+			delivered++
+			if input.MaxTrades > 0 && delivered >= input.MaxTrades {
+				return nil
+			}
+		}
+	}
+	return nil
+}
@@ -3,9 +3,12 @@ package coincheck
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -39,19 +42,19 @@ func TestClient_GetTrades(t *testing.T) {
 				Data: []Trade{
 					{
 						ID:        1,
-						Amount:    1,
-						Rate:      1000000,
+						Amount:    MustParseDecimal("1"),
+						Rate:      MustParseDecimal("1000000"),
 						Pair:      PairETCJPY,
 						OrderType: OrderTypeBuy,
-						CreatedAt: "2021-01-01T00:00:00Z",
+						CreatedAt: NewTime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
 					},
 					{
 						ID:        2,
-						Amount:    2,
-						Rate:      2000000,
+						Amount:    MustParseDecimal("2"),
+						Rate:      MustParseDecimal("2000000"),
 						Pair:      PairETCJPY,
 						OrderType: OrderTypeSell,
-						CreatedAt: "2021-01-02T00:00:00Z",
+						CreatedAt: NewTime(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
 					},
 				},
 			}
@@ -84,23 +87,23 @@ func TestClient_GetTrades(t *testing.T) {
 			Data: []Trade{
 				{
 					ID:        1,
-					Amount:    1,
-					Rate:      1000000,
+					Amount:    MustParseDecimal("1"),
+					Rate:      MustParseDecimal("1000000"),
 					Pair:      PairETCJPY,
 					OrderType: OrderTypeBuy,
-					CreatedAt: "2021-01-01T00:00:00Z",
+					CreatedAt: NewTime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
 				},
 				{
 					ID:        2,
-					Amount:    2,
-					Rate:      2000000,
+					Amount:    MustParseDecimal("2"),
+					Rate:      MustParseDecimal("2000000"),
 					Pair:      PairETCJPY,
 					OrderType: OrderTypeSell,
-					CreatedAt: "2021-01-02T00:00:00Z",
+					CreatedAt: NewTime(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
 				},
 			},
 		}
-		if diff := cmp.Diff(want, got); diff != "" {
+		if diff := cmp.Diff(want, got, equateTime); diff != "" {
 			printDiff(t, diff)
 		}
 	})
@@ -123,3 +126,171 @@ func TestClient_GetTrades(t *testing.T) {
 		}
 	})
 }
+
+func TestClient_GetTradesPagination(t *testing.T) {
+	t.Run("GetTrades sends the pagination parameters", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			wantQuery := "ending_before=100&limit=10&order=desc&pair=btc_jpy"
+			if diff := cmp.Diff(wantQuery, r.URL.RawQuery); diff != "" {
+				printDiff(t, diff)
+			}
+			if err := json.NewEncoder(w).Encode(GetTradesResponse{Success: true}); err != nil {
+				t.Fatal(err)
+			}
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(WithBaseURL(testServer.URL))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = client.GetTrades(context.Background(), GetTradesInput{
+			Pair: PairBTCJPY,
+			Pagination: Pagination{
+				Limit:           10,
+				PaginationOrder: PaginationOrderDesc,
+				EndingBefore:    100,
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestClient_BackfillTrades(t *testing.T) {
+	// newBackfillServer returns a test server that serves trades with ID 1 to 10.
+	// The trade with ID n is created at 2021-01-01T00:00:n. Each page overlaps the previous page by one trade.
+	newBackfillServer := func(t *testing.T) *httptest.Server {
+		t.Helper()
+
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			if diff := cmp.Diff("btc_jpy", q.Get("pair")); diff != "" {
+				printDiff(t, diff)
+			}
+			limit, _ := strconv.Atoi(q.Get("limit"))
+			endingBefore, _ := strconv.Atoi(q.Get("ending_before"))
+
+			from := 10
+			if endingBefore > 0 {
+				from = endingBefore // overlap with the previous page
+			}
+			var data []Trade
+			for id := from; id >= 1 && len(data) < limit; id-- {
+				data = append(data, Trade{
+					ID:        id,
+					Pair:      PairBTCJPY,
+					CreatedAt: NewTime(time.Date(2021, 1, 1, 0, 0, id, 0, time.UTC)),
+				})
+			}
+			if err := json.NewEncoder(w).Encode(GetTradesResponse{Success: true, Data: data}); err != nil {
+				t.Fatal(err)
+			}
+		}))
+	}
+
+	t.Run("BackfillTrades walks backwards and skips duplicated trades", func(t *testing.T) {
+		testServer := newBackfillServer(t)
+		defer testServer.Close()
+
+		client, err := NewClient(WithBaseURL(testServer.URL))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got []int
+		err = client.BackfillTrades(context.Background(), BackfillTradesInput{
+			Pair:  PairBTCJPY,
+			Limit: 3,
+		}, func(trade Trade) error {
+			got = append(got, trade.ID)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []int{10, 9, 8, 7, 6, 5, 4, 3, 2, 1}
+		if diff := cmp.Diff(want, got); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("BackfillTrades starts from the trade ID and stops at the time bound", func(t *testing.T) {
+		testServer := newBackfillServer(t)
+		defer testServer.Close()
+
+		client, err := NewClient(WithBaseURL(testServer.URL))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got []int
+		err = client.BackfillTrades(context.Background(), BackfillTradesInput{
+			Pair:         PairBTCJPY,
+			EndingBefore: 8,
+			Since:        time.Date(2021, 1, 1, 0, 0, 4, 0, time.UTC),
+			Limit:        2,
+		}, func(trade Trade) error {
+			got = append(got, trade.ID)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []int{7, 6, 5, 4}
+		if diff := cmp.Diff(want, got); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("BackfillTrades counts only the delivered trades against MaxTrades", func(t *testing.T) {
+		testServer := newBackfillServer(t)
+		defer testServer.Close()
+
+		client, err := NewClient(WithBaseURL(testServer.URL))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got []int
+		err = client.BackfillTrades(context.Background(), BackfillTradesInput{
+			Pair:      PairBTCJPY,
+			Limit:     3,
+			MaxTrades: 5,
+		}, func(trade Trade) error {
+			got = append(got, trade.ID)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The pages are [10 9 8], [8 7 6], so the duplicated 8 must not be counted.
+		want := []int{10, 9, 8, 7, 6}
+		if diff := cmp.Diff(want, got); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("BackfillTrades returns the error of the callback", func(t *testing.T) {
+		testServer := newBackfillServer(t)
+		defer testServer.Close()
+
+		client, err := NewClient(WithBaseURL(testServer.URL))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		errStop := errors.New("stop")
+		err = client.BackfillTrades(context.Background(), BackfillTradesInput{Pair: PairBTCJPY}, func(Trade) error {
+			return errStop
+		})
+		if !errors.Is(err, errStop) {
+			t.Errorf("error is not errStop: %v", err)
+		}
+	})
+}
@@ -0,0 +1,198 @@
+package coincheck
+
+import (
+	"context"
+	"net/http"
+)
+
+// Liquidity represents whether the transaction was a taker or a maker.
+type Liquidity string
+
+// String returns the string representation of the Liquidity.
+func (l Liquidity) String() string {
+	return string(l)
+}
+
+const (
+	// LiquidityTaker means the transaction was a taker.
+	LiquidityTaker Liquidity = "T"
+	// LiquidityMaker means the transaction was a maker.
+	LiquidityMaker Liquidity = "M"
+)
+
+// Transaction represents your own transaction (fill) of the order.
+type Transaction struct {
+	// ID is the transaction ID.
+	ID int `json:"id"`
+	// OrderID is the order ID.
+	OrderID int `json:"order_id"`
+	// CreatedAt is the creation time of the transaction.
+	CreatedAt Time `json:"created_at"`
+	// Funds is the balance change of each currency. e.g. {"btc": "0.1", "jpy": "-4096.135"}
+	Funds map[Currency]Decimal `json:"funds"`
+	// Pair is the pair of the currency.
+	Pair Pair `json:"pair"`
+	// Rate is the rate of the transaction.
+	Rate Decimal `json:"rate"`
+	// FeeCurrency is the currency of the fee.
+	FeeCurrency Currency `json:"fee_currency"`
+	// Fee is the fee of the transaction.
+	Fee Decimal `json:"fee"`
+	// Liquidity is "T" (taker) or "M" (maker).
+	Liquidity Liquidity `json:"liquidity"`
+	// Side is the side of the transaction (buy or sell).
+	Side OrderType `json:"side"`
+}
+
+// GetTransactionsResponse represents the output from the GetTransactions method.
+type GetTransactionsResponse struct {
+	// Success is a boolean value that indicates the success of the API call.
+	Success bool `json:"success"`
+	// Transactions is a list of your recent transactions.
+	Transactions []Transaction `json:"transactions"`
+}
+
+// GetTransactions returns a list of your recent transactions.
+// API: GET /api/exchange/orders/transactions
+// Visibility: Private
+func (c *Client) GetTransactions(ctx context.Context) (*GetTransactionsResponse, error) {
+	req, err := c.createRequest(ctx, createRequestInput{
+		method:  http.MethodGet,
+		path:    "/api/exchange/orders/transactions",
+		private: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var output GetTransactionsResponse
+	if err := c.do(req, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// GetTransactionsPaginationInput represents the input parameter for the GetTransactionsPagination method.
+type GetTransactionsPaginationInput struct {
+	// Pagination is the pagination of the data. If you don't set it, the Coincheck API uses its default values.
+	Pagination Pagination
+}
+
+// GetTransactionsPaginationResponse represents the output from the GetTransactionsPagination method.
+type GetTransactionsPaginationResponse struct {
+	// Success is a boolean value that indicates the success of the API call.
+	Success bool `json:"success"`
+	// Pagination is the pagination of the data.
+	Pagination Pagination `json:"pagination"`
+	// Data is a list of your transactions.
+	Data []Transaction `json:"data"`
+}
+
+// GetTransactionsPagination returns a list of your transactions with pagination.
+// API: GET /api/exchange/orders/transactions_pagination
+// Visibility: Private
+// If you want to get all transactions, use NewTransactionIterator or NewTransactionsPaginator.
+func (c *Client) GetTransactionsPagination(ctx context.Context, input GetTransactionsPaginationInput) (*GetTransactionsPaginationResponse, error) {
+	req, err := c.createRequest(ctx, createRequestInput{
+		method:     http.MethodGet,
+		path:       "/api/exchange/orders/transactions_pagination",
+		queryParam: input.Pagination.queryParam(),
+		private:    true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var output GetTransactionsPaginationResponse
+	if err := c.do(req, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// NewTransactionsPaginator returns a Paginator over GetTransactionsPagination.
+func (c *Client) NewTransactionsPaginator(input PaginatorInput) *Paginator[Transaction] {
+	fetch := func(ctx context.Context, pagination Pagination) ([]Transaction, error) {
+		resp, err := c.GetTransactionsPagination(ctx, GetTransactionsPaginationInput{Pagination: pagination})
+		if err != nil {
+			return nil, err
+		}
+		return resp.Data, nil
+	}
+	return NewPaginator(fetch, func(tx Transaction) int { return tx.ID }, input)
+}
+
+// TransactionIterator iterates over your transactions across pages of GetTransactionsPagination.
+//
+//	it := client.NewTransactionIterator(coincheck.GetTransactionsPaginationInput{})
+//	for it.Next(ctx) {
+//		tx := it.Transaction()
+//		// ...
+//	}
+//	if err := it.Err(); err != nil {
+//		// ...
+//	}
+type TransactionIterator struct {
+	// paginator fetches the pages.
+	paginator *Paginator[Transaction]
+	// page is the current page.
+	page []Transaction
+	// index is the index of the current transaction in the page.
+	index int
+	// err is the error that occurred while iterating.
+	err error
+}
+
+// NewTransactionIterator returns a new TransactionIterator.
+// The iterator starts from input.Pagination and walks starting_after (asc) or
+// ending_before (desc) until there is no more transaction.
+func (c *Client) NewTransactionIterator(input GetTransactionsPaginationInput) *TransactionIterator {
+	return &TransactionIterator{
+		paginator: c.NewTransactionsPaginator(PaginatorInput{Pagination: input.Pagination}),
+		index:     -1,
+	}
+}
+
+// Next advances the iterator to the next transaction. It returns false when the iteration stops,
+// either by reaching the end or an error (including the cancellation of ctx).
+func (it *TransactionIterator) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+	if err := ctx.Err(); err != nil {
+		it.err = withPrefixError(err)
+		return false
+	}
+
+	if it.index+1 < len(it.page) {
+		it.index++
+		return true
+	}
+
+	for it.paginator.HasNext() {
+		page, err := it.paginator.Next(ctx)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		if len(page) > 0 {
+			it.page = page
+			it.index = 0
+			return true
+		}
+	}
+	return false
+}
+
+// Transaction returns the current transaction.
+func (it *TransactionIterator) Transaction() Transaction {
+	if it.index < 0 || it.index >= len(it.page) {
+		return Transaction{}
+	}
+	return it.page[it.index]
+}
+
+// Err returns the error that occurred while iterating.
+func (it *TransactionIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,287 @@
+package coincheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_GetTransactions(t *testing.T) {
+	t.Run("GetTransactions returns a list of transactions", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			wantMethod := http.MethodGet
+			if diff := cmp.Diff(wantMethod, r.Method); diff != "" {
+				printDiff(t, diff)
+			}
+
+			wantEndpoint := "/api/exchange/orders/transactions"
+			if diff := cmp.Diff(wantEndpoint, r.URL.Path); diff != "" {
+				printDiff(t, diff)
+			}
+
+			w.Write([]byte(`{
+				"success": true,
+				"transactions": [
+					{
+						"id": 38,
+						"order_id": 49,
+						"created_at": "2015-11-18T07:02:21.000Z",
+						"funds": {"btc": "0.1", "jpy": "-4096.135"},
+						"pair": "btc_jpy",
+						"rate": "40900.0",
+						"fee_currency": "JPY",
+						"fee": "6.135",
+						"liquidity": "T",
+						"side": "buy"
+					}
+				]
+			}`)) //nolint: errcheck // ignore error
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := client.GetTransactions(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := &GetTransactionsResponse{
+			Success: true,
+			Transactions: []Transaction{
+				{
+					ID:          38,
+					OrderID:     49,
+					CreatedAt:   NewTime(time.Date(2015, 11, 18, 7, 2, 21, 0, time.UTC)),
+					Funds:       map[Currency]Decimal{CurrencyBTC: MustParseDecimal("0.1"), CurrencyJPY: MustParseDecimal("-4096.135")},
+					Pair:        PairBTCJPY,
+					Rate:        MustParseDecimal("40900.0"),
+					FeeCurrency: CurrencyJPY,
+					Fee:         MustParseDecimal("6.135"),
+					Liquidity:   LiquidityTaker,
+					Side:        OrderTypeBuy,
+				},
+			},
+		}
+		if diff := cmp.Diff(want, got, equateTime); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("GetTransactions returns an error if client does not set credentials", func(t *testing.T) {
+		client, err := NewClient(WithBaseURL("https://example.com"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = client.GetTransactions(context.Background()); !errors.Is(err, ErrNoCredentials) {
+			t.Errorf("error is not ErrNoCredentials: %v", err)
+		}
+	})
+}
+
+func TestClient_GetTransactionsPagination(t *testing.T) {
+	t.Run("GetTransactionsPagination sends the pagination parameters", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			wantEndpoint := "/api/exchange/orders/transactions_pagination"
+			if diff := cmp.Diff(wantEndpoint, r.URL.Path); diff != "" {
+				printDiff(t, diff)
+			}
+
+			wantQuery := "ending_before=100&limit=2&order=desc"
+			if diff := cmp.Diff(wantQuery, r.URL.RawQuery); diff != "" {
+				printDiff(t, diff)
+			}
+
+			result := GetTransactionsPaginationResponse{
+				Success: true,
+				Pagination: Pagination{
+					Limit:           2,
+					PaginationOrder: PaginationOrderDesc,
+					EndingBefore:    100,
+				},
+				Data: []Transaction{
+					{ID: 99, OrderID: 1, Pair: PairBTCJPY, Side: OrderTypeSell, Liquidity: LiquidityMaker},
+				},
+			}
+			if err := json.NewEncoder(w).Encode(result); err != nil {
+				t.Fatal(err)
+			}
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := client.GetTransactionsPagination(context.Background(), GetTransactionsPaginationInput{
+			Pagination: Pagination{
+				Limit:           2,
+				PaginationOrder: PaginationOrderDesc,
+				EndingBefore:    100,
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := &GetTransactionsPaginationResponse{
+			Success: true,
+			Pagination: Pagination{
+				Limit:           2,
+				PaginationOrder: PaginationOrderDesc,
+				EndingBefore:    100,
+			},
+			Data: []Transaction{
+				{ID: 99, OrderID: 1, Pair: PairBTCJPY, Side: OrderTypeSell, Liquidity: LiquidityMaker},
+			},
+		}
+		if diff := cmp.Diff(want, got, equateTime); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+}
+
+// newTransactionsServer returns a test server that serves transactions with ID 1 to total
+// in the same way as GET /api/exchange/orders/transactions_pagination.
+func newTransactionsServer(t *testing.T, total int) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		if limit == 0 {
+			limit = 25
+		}
+		startingAfter, _ := strconv.Atoi(q.Get("starting_after"))
+		endingBefore, _ := strconv.Atoi(q.Get("ending_before"))
+
+		var data []Transaction
+		if q.Get("order") == string(PaginationOrderAsc) {
+			for id := startingAfter + 1; id <= total && len(data) < limit; id++ {
+				if endingBefore == 0 || id < endingBefore {
+					data = append(data, Transaction{ID: id})
+				}
+			}
+		} else {
+			from := total
+			if endingBefore > 0 {
+				from = endingBefore - 1
+			}
+			for id := from; id > startingAfter && len(data) < limit; id-- {
+				data = append(data, Transaction{ID: id})
+			}
+		}
+
+		result := GetTransactionsPaginationResponse{Success: true, Data: data}
+		if err := json.NewEncoder(w).Encode(result); err != nil {
+			t.Fatal(err)
+		}
+	}))
+}
+
+func TestTransactionIterator(t *testing.T) {
+	t.Run("TransactionIterator walks all pages in descending order", func(t *testing.T) {
+		testServer := newTransactionsServer(t, 7)
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		it := client.NewTransactionIterator(GetTransactionsPaginationInput{
+			Pagination: Pagination{Limit: 3, PaginationOrder: PaginationOrderDesc},
+		})
+		var got []int
+		for it.Next(context.Background()) {
+			got = append(got, it.Transaction().ID)
+		}
+		if err := it.Err(); err != nil {
+			t.Fatal(err)
+		}
+
+		want := []int{7, 6, 5, 4, 3, 2, 1}
+		if diff := cmp.Diff(want, got); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("TransactionIterator walks all pages in ascending order", func(t *testing.T) {
+		testServer := newTransactionsServer(t, 5)
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		it := client.NewTransactionIterator(GetTransactionsPaginationInput{
+			Pagination: Pagination{Limit: 2, PaginationOrder: PaginationOrderAsc, StartingAfter: 1},
+		})
+		var got []int
+		for it.Next(context.Background()) {
+			got = append(got, it.Transaction().ID)
+		}
+		if err := it.Err(); err != nil {
+			t.Fatal(err)
+		}
+
+		want := []int{2, 3, 4, 5}
+		if diff := cmp.Diff(want, got); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("TransactionIterator stops if the context is cancelled", func(t *testing.T) {
+		testServer := newTransactionsServer(t, 10)
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		it := client.NewTransactionIterator(GetTransactionsPaginationInput{
+			Pagination: Pagination{Limit: 2},
+		})
+		if !it.Next(ctx) {
+			t.Fatal(it.Err())
+		}
+		cancel()
+
+		if it.Next(ctx) {
+			t.Error("Next must return false after the context is cancelled")
+		}
+		if !errors.Is(it.Err(), context.Canceled) {
+			t.Errorf("error is not context.Canceled: %v", it.Err())
+		}
+	})
+}
@@ -2,7 +2,25 @@ package coincheck
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strconv"
+	"unicode"
+)
+
+// BankAccountType represents the type of a Japanese bank account.
+type BankAccountType string
+
+// String returns the string representation of the BankAccountType.
+func (b BankAccountType) String() string {
+	return string(b)
+}
+
+const (
+	// BankAccountTypeFutsu is an ordinary deposit account (普通預金).
+	BankAccountTypeFutsu BankAccountType = "futsu"
+	// BankAccountTypeToza is a current account (当座預金).
+	BankAccountTypeToza BankAccountType = "toza"
 )
 
 // GetBankAccountsResponse represents the response from the GetBankAccounts API.
@@ -10,7 +28,7 @@ type GetBankAccountsResponse struct {
 	// Success is a boolean value that indicates the success of the API call.
 	Success bool `json:"success"`
 	// Data is a list of bank accounts.
-	Data []BankAccount
+	Data []BankAccount `json:"data"`
 }
 
 // BankAccount represents a bank account.
@@ -21,8 +39,8 @@ type BankAccount struct {
 	BankName string `json:"bank_name"`
 	// BranchName is the branch name.
 	BranchName string `json:"branch_name"`
-	// BankAccountType is the bank account type.
-	BankAccountType string `json:"bank_account_type"`
+	// BankAccountType is the bank account type (futsu or toza).
+	BankAccountType BankAccountType `json:"bank_account_type"`
 	// Number is the bank account number.
 	Number string `json:"number"`
 	// Name is the bank account name.
@@ -48,3 +66,353 @@ func (c *Client) GetBankAccounts(ctx context.Context) (*GetBankAccountsResponse,
 	}
 	return &output, nil
 }
+
+// CreateBankAccountInput represents the input parameter for the CreateBankAccount method.
+type CreateBankAccountInput struct {
+	// BankName is the bank name. e.g. みずほ
+	BankName string
+	// BranchName is the branch name. e.g. 東京営業部
+	BranchName string
+	// BankAccountType is the bank account type (futsu or toza).
+	BankAccountType BankAccountType
+	// Number is the 7 digits bank account number. e.g. 0123456
+	Number string
+	// Name is the account holder name in katakana. e.g. カ）コインチェック
+	Name string
+}
+
+// validate validates the CreateBankAccountInput.
+func (i CreateBankAccountInput) validate() error {
+	if i.BankName == "" {
+		return fmt.Errorf("%w: bank_name is required", ErrInvalidBankAccount)
+	}
+	if i.BranchName == "" {
+		return fmt.Errorf("%w: branch_name is required", ErrInvalidBankAccount)
+	}
+	switch i.BankAccountType {
+	case BankAccountTypeFutsu, BankAccountTypeToza:
+	default:
+		return fmt.Errorf("%w: unknown bank account type %q", ErrInvalidBankAccount, i.BankAccountType)
+	}
+	if !validBankAccountNumber(i.Number) {
+		return fmt.Errorf("%w: number must be 7 digits: %q", ErrInvalidBankAccount, i.Number)
+	}
+	if !validBankAccountName(i.Name) {
+		return fmt.Errorf("%w: name must be katakana: %q", ErrInvalidBankAccount, i.Name)
+	}
+	return nil
+}
+
+// validBankAccountNumber returns true if number is 7 digits.
+func validBankAccountNumber(number string) bool {
+	if len(number) != 7 {
+		return false
+	}
+	for _, r := range number {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// validBankAccountName returns true if name is katakana. Spaces and the symbols used in
+// the names of Japanese bank accounts (e.g. "カ）" for 株式会社) are also allowed.
+// The voiced sound marks (e.g. "ﾞ" in "ﾀﾞ") are not in the Katakana script, so they are listed explicitly.
+func validBankAccountName(name string) bool {
+	hasKatakana := false
+	for _, r := range name {
+		switch {
+		case unicode.Is(unicode.Katakana, r):
+			hasKatakana = true
+		case r == 'ー', r == 'ｰ', r == '・', r == '･':
+		case r == 'ﾞ', r == 'ﾟ', r == '゛', r == '゜':
+		case r == ' ', r == '\u3000':
+		case r == '（', r == '）', r == '．', r == '－', r == '／', r == '，':
+		case r == '(', r == ')', r == '.', r == '-', r == '/', r == ',':
+		default:
+			return false
+		}
+	}
+	return hasKatakana
+}
+
+// createBankAccountRequestBody is the request body for POST /api/bank_accounts.
+type createBankAccountRequestBody struct {
+	BankName        string          `json:"bank_name"`
+	BranchName      string          `json:"branch_name"`
+	BankAccountType BankAccountType `json:"bank_account_type"`
+	Number          string          `json:"number"`
+	Name            string          `json:"name"`
+}
+
+// CreateBankAccountResponse represents the output from the CreateBankAccount method.
+type CreateBankAccountResponse struct {
+	// Success is a boolean value that indicates the success of the API call.
+	Success bool `json:"success"`
+	// Data is the registered bank account.
+	Data BankAccount `json:"data"`
+}
+
+// CreateBankAccount registers a bank account for withdrawal.
+// API: POST /api/bank_accounts
+// Visibility: Private
+// The input is validated before sending the request (the account number must be 7 digits,
+// and the name must be katakana), so invalid input is reported as ErrInvalidBankAccount
+// without calling the Coincheck API.
+func (c *Client) CreateBankAccount(ctx context.Context, input CreateBankAccountInput) (*CreateBankAccountResponse, error) {
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+
+	req, err := c.createRequest(ctx, createRequestInput{
+		method: http.MethodPost,
+		path:   "/api/bank_accounts",
+		body: createBankAccountRequestBody{
+			BankName:        input.BankName,
+			BranchName:      input.BranchName,
+			BankAccountType: input.BankAccountType,
+			Number:          input.Number,
+			Name:            input.Name,
+		},
+		private: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var output CreateBankAccountResponse
+	if err := c.do(req, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// DeleteBankAccountInput represents the input parameter for the DeleteBankAccount method.
+type DeleteBankAccountInput struct {
+	// ID is the bank account ID.
+	ID int
+}
+
+// DeleteBankAccountResponse represents the output from the DeleteBankAccount method.
+type DeleteBankAccountResponse struct {
+	// Success is a boolean value that indicates the success of the API call.
+	Success bool `json:"success"`
+}
+
+// DeleteBankAccount deletes the bank account.
+// API: DELETE /api/bank_accounts/[id]
+// Visibility: Private
+func (c *Client) DeleteBankAccount(ctx context.Context, input DeleteBankAccountInput) (*DeleteBankAccountResponse, error) {
+	req, err := c.createRequest(ctx, createRequestInput{
+		method:  http.MethodDelete,
+		path:    "/api/bank_accounts/" + strconv.Itoa(input.ID),
+		private: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var output DeleteBankAccountResponse
+	if err := c.do(req, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// WithdrawalStatus represents the status of a JPY withdrawal.
+type WithdrawalStatus string
+
+// String returns the string representation of the WithdrawalStatus.
+func (s WithdrawalStatus) String() string {
+	return string(s)
+}
+
+const (
+	// WithdrawalStatusPending means the withdrawal is waiting to be processed. It can be cancelled.
+	WithdrawalStatusPending WithdrawalStatus = "pending"
+	// WithdrawalStatusProcessing means the withdrawal is being processed.
+	WithdrawalStatusProcessing WithdrawalStatus = "processing"
+	// WithdrawalStatusFinished means the withdrawal was paid into the bank account.
+	WithdrawalStatusFinished WithdrawalStatus = "finished"
+	// WithdrawalStatusCanceled means the withdrawal was cancelled.
+	WithdrawalStatusCanceled WithdrawalStatus = "canceled"
+)
+
+// Withdrawal represents a JPY withdrawal to your bank account.
+type Withdrawal struct {
+	// ID is the withdrawal ID.
+	ID int `json:"id"`
+	// Status is the status of the withdrawal.
+	Status WithdrawalStatus `json:"status"`
+	// Amount is the withdrawal amount.
+	Amount Decimal `json:"amount"`
+	// Currency is the currency of the withdrawal (jpy).
+	Currency Currency `json:"currency"`
+	// CreatedAt is the creation time of the withdrawal.
+	CreatedAt Time `json:"created_at"`
+	// BankAccountID is the ID of the BankAccount that the withdrawal pays into.
+	BankAccountID int `json:"bank_account_id"`
+	// Fee is the fee of the withdrawal.
+	Fee Decimal `json:"fee"`
+	// IsFast is true if the withdrawal is a fast withdrawal.
+	IsFast bool `json:"is_fast"`
+}
+
+// BankAccount returns the bank account that the withdrawal pays into from accounts
+// (e.g. GetBankAccountsResponse.Data). It returns false if the bank account is not in accounts.
+func (w Withdrawal) BankAccount(accounts []BankAccount) (BankAccount, bool) {
+	for _, account := range accounts {
+		if account.ID == w.BankAccountID {
+			return account, true
+		}
+	}
+	return BankAccount{}, false
+}
+
+// CreateWithdrawalInput represents the input parameter for the CreateWithdrawal method.
+type CreateWithdrawalInput struct {
+	// BankAccountID is the ID of the BankAccount to pay into. See GetBankAccounts.
+	BankAccountID int
+	// Amount is the withdrawal amount in JPY. e.g. 10000
+	Amount Decimal
+}
+
+// validate validates the CreateWithdrawalInput.
+func (i CreateWithdrawalInput) validate() error {
+	if i.BankAccountID <= 0 {
+		return fmt.Errorf("%w: bank_account_id is required", ErrInvalidWithdrawal)
+	}
+	if i.Amount.Sign() <= 0 {
+		return fmt.Errorf("%w: amount must be greater than 0", ErrInvalidWithdrawal)
+	}
+	return nil
+}
+
+// createWithdrawalRequestBody is the request body for POST /api/withdraws.
+type createWithdrawalRequestBody struct {
+	BankAccountID int    `json:"bank_account_id"`
+	Amount        string `json:"amount"`
+	Currency      string `json:"currency"`
+}
+
+// CreateWithdrawalResponse represents the output from the CreateWithdrawal method.
+type CreateWithdrawalResponse struct {
+	// Success is a boolean value that indicates the success of the API call.
+	Success bool `json:"success"`
+	// Data is the requested withdrawal.
+	Data Withdrawal `json:"data"`
+}
+
+// CreateWithdrawal requests a JPY withdrawal to your bank account.
+// API: POST /api/withdraws
+// Visibility: Private
+// The input is validated before sending the request, so invalid input is reported as
+// ErrInvalidWithdrawal without calling the Coincheck API.
+func (c *Client) CreateWithdrawal(ctx context.Context, input CreateWithdrawalInput) (*CreateWithdrawalResponse, error) {
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+
+	req, err := c.createRequest(ctx, createRequestInput{
+		method: http.MethodPost,
+		path:   "/api/withdraws",
+		body: createWithdrawalRequestBody{
+			BankAccountID: input.BankAccountID,
+			Amount:        input.Amount.String(),
+			Currency:      "JPY",
+		},
+		private: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var output CreateWithdrawalResponse
+	if err := c.do(req, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// ListWithdrawalsInput represents the input parameter for the ListWithdrawals method.
+type ListWithdrawalsInput struct {
+	// Pagination is the pagination of the data. If you don't set it, the Coincheck API uses its default values.
+	Pagination Pagination
+}
+
+// ListWithdrawalsResponse represents the output from the ListWithdrawals method.
+type ListWithdrawalsResponse struct {
+	// Success is a boolean value that indicates the success of the API call.
+	Success bool `json:"success"`
+	// Pagination is the pagination of the data.
+	Pagination Pagination `json:"pagination"`
+	// Data is a list of your withdrawals.
+	Data []Withdrawal `json:"data"`
+}
+
+// ListWithdrawals returns a list of your JPY withdrawals with pagination.
+// API: GET /api/withdraws
+// Visibility: Private
+// If you want to get all withdrawals, use NewWithdrawalsPaginator.
+func (c *Client) ListWithdrawals(ctx context.Context, input ListWithdrawalsInput) (*ListWithdrawalsResponse, error) {
+	req, err := c.createRequest(ctx, createRequestInput{
+		method:     http.MethodGet,
+		path:       "/api/withdraws",
+		queryParam: input.Pagination.queryParam(),
+		private:    true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var output ListWithdrawalsResponse
+	if err := c.do(req, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// NewWithdrawalsPaginator returns a Paginator over ListWithdrawals.
+func (c *Client) NewWithdrawalsPaginator(input PaginatorInput) *Paginator[Withdrawal] {
+	fetch := func(ctx context.Context, pagination Pagination) ([]Withdrawal, error) {
+		resp, err := c.ListWithdrawals(ctx, ListWithdrawalsInput{Pagination: pagination})
+		if err != nil {
+			return nil, err
+		}
+		return resp.Data, nil
+	}
+	return NewPaginator(fetch, func(w Withdrawal) int { return w.ID }, input)
+}
+
+// CancelWithdrawalInput represents the input parameter for the CancelWithdrawal method.
+type CancelWithdrawalInput struct {
+	// ID is the withdrawal ID.
+	ID int
+}
+
+// CancelWithdrawalResponse represents the output from the CancelWithdrawal method.
+type CancelWithdrawalResponse struct {
+	// Success is a boolean value that indicates the success of the API call.
+	Success bool `json:"success"`
+}
+
+// CancelWithdrawal cancels the withdrawal. Only a pending withdrawal can be cancelled.
+// API: DELETE /api/withdraws/[id]
+// Visibility: Private
+func (c *Client) CancelWithdrawal(ctx context.Context, input CancelWithdrawalInput) (*CancelWithdrawalResponse, error) {
+	req, err := c.createRequest(ctx, createRequestInput{
+		method:  http.MethodDelete,
+		path:    "/api/withdraws/" + strconv.Itoa(input.ID),
+		private: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var output CancelWithdrawalResponse
+	if err := c.do(req, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
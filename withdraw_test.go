@@ -6,7 +6,9 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -125,3 +127,358 @@ func TestClientGetBankAccounts(t *testing.T) {
 		}
 	})
 }
+
+func TestClientCreateBankAccount(t *testing.T) {
+	t.Run("CreateBankAccount registers a bank account", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			wantMethod := http.MethodPost
+			if diff := cmp.Diff(wantMethod, r.Method); diff != "" {
+				printDiff(t, diff)
+			}
+
+			wantEndpoint := "/api/bank_accounts"
+			if diff := cmp.Diff(wantEndpoint, r.URL.Path); diff != "" {
+				printDiff(t, diff)
+			}
+
+			var body map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			wantBody := map[string]string{
+				"bank_name":         "みずほ",
+				"branch_name":       "東京営業部",
+				"bank_account_type": "futsu",
+				"number":            "0123456",
+				"name":              "カ）コインチェック",
+			}
+			if diff := cmp.Diff(wantBody, body); diff != "" {
+				printDiff(t, diff)
+			}
+
+			w.Write([]byte(`{"success":true,"data":{"id":641,"bank_name":"みずほ","branch_name":"東京営業部","bank_account_type":"futsu","number":"0123456","name":"カ）コインチェック"}}`)) //nolint: errcheck // ignore error
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := client.CreateBankAccount(context.Background(), CreateBankAccountInput{
+			BankName:        "みずほ",
+			BranchName:      "東京営業部",
+			BankAccountType: BankAccountTypeFutsu,
+			Number:          "0123456",
+			Name:            "カ）コインチェック",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := &CreateBankAccountResponse{
+			Success: true,
+			Data: BankAccount{
+				ID:              641,
+				BankName:        "みずほ",
+				BranchName:      "東京営業部",
+				BankAccountType: BankAccountTypeFutsu,
+				Number:          "0123456",
+				Name:            "カ）コインチェック",
+			},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+
+	t.Run("CreateBankAccount validates the input before sending the request", func(t *testing.T) {
+		var called atomic.Int32
+		testServer := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+			called.Add(1)
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		valid := CreateBankAccountInput{
+			BankName:        "みずほ",
+			BranchName:      "東京営業部",
+			BankAccountType: BankAccountTypeToza,
+			Number:          "0123456",
+			Name:            "ヤマダ タロウ",
+		}
+		tests := []struct {
+			name   string
+			modify func(*CreateBankAccountInput)
+		}{
+			{name: "no bank name", modify: func(i *CreateBankAccountInput) { i.BankName = "" }},
+			{name: "no branch name", modify: func(i *CreateBankAccountInput) { i.BranchName = "" }},
+			{name: "unknown account type", modify: func(i *CreateBankAccountInput) { i.BankAccountType = "savings" }},
+			{name: "6 digits number", modify: func(i *CreateBankAccountInput) { i.Number = "123456" }},
+			{name: "non digit number", modify: func(i *CreateBankAccountInput) { i.Number = "012345a" }},
+			{name: "kanji name", modify: func(i *CreateBankAccountInput) { i.Name = "山田 太郎" }},
+			{name: "latin name", modify: func(i *CreateBankAccountInput) { i.Name = "Taro Yamada" }},
+			{name: "empty name", modify: func(i *CreateBankAccountInput) { i.Name = "" }},
+		}
+		for _, tt := range tests {
+			input := valid
+			tt.modify(&input)
+			if _, err := client.CreateBankAccount(context.Background(), input); !errors.Is(err, ErrInvalidBankAccount) {
+				t.Errorf("%s: error is not ErrInvalidBankAccount: %v", tt.name, err)
+			}
+		}
+		if called.Load() != 0 {
+			t.Errorf("the server was called %d times", called.Load())
+		}
+	})
+}
+
+func Test_validBankAccountName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{name: "ヤマダ タロウ", want: true},
+		{name: "ヤマダ　タロウ", want: true},
+		{name: "カ）コインチェック", want: true},
+		{name: "ｺｲﾝﾁｪｯｸ(ｶ", want: true},
+		{name: "スズキ・ジョー", want: true},
+		{name: "ﾔﾏﾀﾞ ﾀﾛｳ", want: true},
+		{name: "ｶﾌﾞｼｷｶﾞｲｼｬ ﾎﾟﾝ", want: true},
+		{name: "ﾔﾏﾀﾞ　ﾀﾛｰ", want: true},
+		{name: "ﾞﾟ", want: false},
+		{name: "やまだ たろう", want: false},
+		{name: "ー", want: false},
+	}
+	for _, tt := range tests {
+		if got := validBankAccountName(tt.name); got != tt.want {
+			t.Errorf("validBankAccountName(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestClientDeleteBankAccount(t *testing.T) {
+	t.Run("DeleteBankAccount deletes the bank account", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			wantMethod := http.MethodDelete
+			if diff := cmp.Diff(wantMethod, r.Method); diff != "" {
+				printDiff(t, diff)
+			}
+
+			wantEndpoint := "/api/bank_accounts/641"
+			if diff := cmp.Diff(wantEndpoint, r.URL.Path); diff != "" {
+				printDiff(t, diff)
+			}
+
+			w.Write([]byte(`{"success":true}`)) //nolint: errcheck // ignore error
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := client.DeleteBankAccount(context.Background(), DeleteBankAccountInput{ID: 641})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(&DeleteBankAccountResponse{Success: true}, got); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+}
+
+func TestClientCreateWithdrawal(t *testing.T) {
+	t.Run("CreateWithdrawal requests a JPY withdrawal", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			wantMethod := http.MethodPost
+			if diff := cmp.Diff(wantMethod, r.Method); diff != "" {
+				printDiff(t, diff)
+			}
+
+			wantEndpoint := "/api/withdraws"
+			if diff := cmp.Diff(wantEndpoint, r.URL.Path); diff != "" {
+				printDiff(t, diff)
+			}
+
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			wantBody := map[string]any{
+				"bank_account_id": float64(243),
+				"amount":          "242742",
+				"currency":        "JPY",
+			}
+			if diff := cmp.Diff(wantBody, body); diff != "" {
+				printDiff(t, diff)
+			}
+
+			w.Write([]byte(`{"success":true,"data":{"id":398,"status":"pending","amount":"242742.0","currency":"JPY","created_at":"2014-12-04T15:00:00.000Z","bank_account_id":243,"fee":"400.0","is_fast":false}}`)) //nolint: errcheck // ignore error
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := client.CreateWithdrawal(context.Background(), CreateWithdrawalInput{
+			BankAccountID: 243,
+			Amount:        MustParseDecimal("242742"),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		withdrawal := got.Data
+		if withdrawal.ID != 398 || withdrawal.Status != WithdrawalStatusPending || withdrawal.Currency != CurrencyJPY ||
+			!withdrawal.Amount.Equal(MustParseDecimal("242742")) || !withdrawal.Fee.Equal(MustParseDecimal("400")) ||
+			withdrawal.BankAccountID != 243 || withdrawal.IsFast ||
+			!withdrawal.CreatedAt.Equal(time.Date(2014, 12, 4, 15, 0, 0, 0, time.UTC)) {
+			t.Errorf("unexpected withdrawal: %+v", withdrawal)
+		}
+
+		accounts := []BankAccount{{ID: 1}, {ID: 243, BankName: "みずほ"}}
+		account, ok := withdrawal.BankAccount(accounts)
+		if !ok || account.BankName != "みずほ" {
+			t.Errorf("bank account is not found: %+v", account)
+		}
+		if _, ok := withdrawal.BankAccount(accounts[:1]); ok {
+			t.Error("bank account must not be found")
+		}
+	})
+
+	t.Run("CreateWithdrawal validates the input before sending the request", func(t *testing.T) {
+		client, err := NewClient(
+			WithBaseURL("https://example.com"),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, input := range []CreateWithdrawalInput{
+			{Amount: MustParseDecimal("10000")},
+			{BankAccountID: 243},
+			{BankAccountID: 243, Amount: MustParseDecimal("-1")},
+		} {
+			if _, err := client.CreateWithdrawal(context.Background(), input); !errors.Is(err, ErrInvalidWithdrawal) {
+				t.Errorf("%+v: error is not ErrInvalidWithdrawal: %v", input, err)
+			}
+		}
+	})
+}
+
+func TestClientListWithdrawals(t *testing.T) {
+	t.Run("ListWithdrawals returns a list of withdrawals", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			wantMethod := http.MethodGet
+			if diff := cmp.Diff(wantMethod, r.Method); diff != "" {
+				printDiff(t, diff)
+			}
+
+			wantEndpoint := "/api/withdraws"
+			if diff := cmp.Diff(wantEndpoint, r.URL.Path); diff != "" {
+				printDiff(t, diff)
+			}
+
+			wantQuery := "limit=25&order=desc"
+			if diff := cmp.Diff(wantQuery, r.URL.RawQuery); diff != "" {
+				printDiff(t, diff)
+			}
+
+			w.Write([]byte(`{
+				"success": true,
+				"pagination": {"limit": 25, "order": "desc", "starting_after": null, "ending_before": null},
+				"data": [
+					{"id": 398, "status": "finished", "amount": "242742.0", "currency": "JPY", "created_at": "2014-12-04T15:00:00.000Z", "bank_account_id": 243, "fee": "400.0", "is_fast": true},
+					{"id": 397, "status": "canceled", "amount": "1000.0", "currency": "JPY", "created_at": "2014-12-03T15:00:00.000Z", "bank_account_id": 243, "fee": "400.0", "is_fast": false}
+				]
+			}`)) //nolint: errcheck // ignore error
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := client.ListWithdrawals(context.Background(), ListWithdrawalsInput{
+			Pagination: Pagination{Limit: 25, PaginationOrder: PaginationOrderDesc},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(got.Data) != 2 {
+			t.Fatalf("got %d withdrawals, want 2", len(got.Data))
+		}
+		if got.Data[0].Status != WithdrawalStatusFinished || !got.Data[0].IsFast {
+			t.Errorf("unexpected withdrawal: %+v", got.Data[0])
+		}
+		if got.Data[1].Status != WithdrawalStatusCanceled || got.Data[1].IsFast {
+			t.Errorf("unexpected withdrawal: %+v", got.Data[1])
+		}
+		if diff := cmp.Diff(Pagination{Limit: 25, PaginationOrder: PaginationOrderDesc}, got.Pagination); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+}
+
+func TestClientCancelWithdrawal(t *testing.T) {
+	t.Run("CancelWithdrawal cancels the withdrawal", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			wantMethod := http.MethodDelete
+			if diff := cmp.Diff(wantMethod, r.Method); diff != "" {
+				printDiff(t, diff)
+			}
+
+			wantEndpoint := "/api/withdraws/398"
+			if diff := cmp.Diff(wantEndpoint, r.URL.Path); diff != "" {
+				printDiff(t, diff)
+			}
+
+			w.Write([]byte(`{"success":true}`)) //nolint: errcheck // ignore error
+		}))
+		defer testServer.Close()
+
+		client, err := NewClient(
+			WithBaseURL(testServer.URL),
+			WithCredentials("api_key", "api_secret"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := client.CancelWithdrawal(context.Background(), CancelWithdrawalInput{ID: 398})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(&CancelWithdrawalResponse{Success: true}, got); diff != "" {
+			printDiff(t, diff)
+		}
+	})
+}